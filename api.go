@@ -12,6 +12,8 @@ type Translation struct {
 	Text                   string `json:"text"`
 	// BilledCharacters has the value only if ShowBilledChars(true) option was set.
 	BilledCharacters int `json:"billed_characters"`
+	// ModelTypeUsed has the value only if the ModelType option was set.
+	ModelTypeUsed Model `json:"model_type_used"`
 }
 
 // Glossary as per
@@ -32,3 +34,144 @@ type GlossaryEntry struct {
 	Source string
 	Target string
 }
+
+// A Document is a document that has been uploaded to DeepL for translation.
+// The Key is required to query the status and download the result.
+type Document struct {
+	ID  string `json:"document_id"`
+	Key string `json:"document_key"`
+}
+
+// DocumentState is the translation state of an uploaded document.
+type DocumentState string
+
+const (
+	// DocumentQueued means the translation has not started yet.
+	DocumentQueued DocumentState = "queued"
+	// DocumentTranslating means the document is currently being translated.
+	DocumentTranslating DocumentState = "translating"
+	// DocumentDone means the translation is done and the result can be downloaded.
+	DocumentDone DocumentState = "done"
+	// DocumentError means an irrecoverable error occurred while translating.
+	DocumentError DocumentState = "error"
+)
+
+// DocumentStatus as per
+// https://www.deepl.com/docs-api/documents/get-document-status
+type DocumentStatus struct {
+	DocumentID string        `json:"document_id"`
+	Status     DocumentState `json:"status"`
+	// SecondsRemaining is an estimate of the seconds until the translation is
+	// done. It is only set while the document is being translated.
+	SecondsRemaining int `json:"seconds_remaining"`
+	// BilledCharacters is only set when the translation is done.
+	BilledCharacters int    `json:"billed_characters"`
+	ErrorMessage     string `json:"error_message"`
+}
+
+// Done returns whether the document translation has finished, either
+// successfully or with an error.
+func (s DocumentStatus) Done() bool {
+	return s.Status == DocumentDone || s.Status == DocumentError
+}
+
+// Usage as per
+// https://www.deepl.com/docs-api/general/get-usage
+//
+// Limits that are not set for the account are zero.
+type Usage struct {
+	CharacterCount    int `json:"character_count"`
+	CharacterLimit    int `json:"character_limit"`
+	DocumentCount     int `json:"document_count"`
+	DocumentLimit     int `json:"document_limit"`
+	TeamDocumentCount int `json:"team_document_count"`
+	TeamDocumentLimit int `json:"team_document_limit"`
+}
+
+// Remaining returns the number of characters that can still be translated in
+// the current billing period. If the account has no character limit,
+// Remaining returns -1.
+func (u Usage) Remaining() int {
+	if u.CharacterLimit <= 0 {
+		return -1
+	}
+	if u.CharacterCount >= u.CharacterLimit {
+		return 0
+	}
+	return u.CharacterLimit - u.CharacterCount
+}
+
+// RemainingDocuments returns the number of documents that can still be
+// translated in the current billing period. If the account has no document
+// limit, RemainingDocuments returns -1.
+func (u Usage) RemainingDocuments() int {
+	if u.DocumentLimit <= 0 {
+		return -1
+	}
+	if u.DocumentCount >= u.DocumentLimit {
+		return 0
+	}
+	return u.DocumentLimit - u.DocumentCount
+}
+
+// Exceeded returns whether any of the limits has been reached. When a limit
+// is reached, DeepL responds with a "Quota exceeded" error (HTTP 456).
+func (u Usage) Exceeded() bool {
+	return limitReached(u.CharacterCount, u.CharacterLimit) ||
+		limitReached(u.DocumentCount, u.DocumentLimit) ||
+		limitReached(u.TeamDocumentCount, u.TeamDocumentLimit)
+}
+
+func limitReached(count, limit int) bool {
+	return limit > 0 && count >= limit
+}
+
+// LanguageInfo as per
+// https://www.deepl.com/docs-api/general/get-languages
+type LanguageInfo struct {
+	Code Language `json:"language"`
+	Name string   `json:"name"`
+	// SupportsFormality is only reported for target languages.
+	SupportsFormality bool `json:"supports_formality"`
+}
+
+// GlossaryLanguagePair is a source→target language pair that is supported
+// by glossaries.
+type GlossaryLanguagePair struct {
+	SourceLang Language `json:"source_lang"`
+	TargetLang Language `json:"target_lang"`
+}
+
+// Improvement is a text improvement result from DeepL Write.
+type Improvement struct {
+	Text                   string `json:"text"`
+	TargetLanguage         string `json:"target_language"`
+	DetectedSourceLanguage string `json:"detected_source_language"`
+}
+
+// MultilingualGlossary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries
+//
+// A MultilingualGlossary contains a dictionary for every source→target
+// language pair.
+type MultilingualGlossary struct {
+	GlossaryID   string                   `json:"glossary_id"`
+	Name         string                   `json:"name"`
+	Dictionaries []GlossaryDictionaryInfo `json:"dictionaries"`
+	CreationTime time.Time                `json:"creation_time"`
+}
+
+// GlossaryDictionaryInfo describes a dictionary of a MultilingualGlossary.
+type GlossaryDictionaryInfo struct {
+	SourceLang Language `json:"source_lang"`
+	TargetLang Language `json:"target_lang"`
+	EntryCount int      `json:"entry_count"`
+}
+
+// A GlossaryDictionary contains the entries of a MultilingualGlossary for a
+// single source→target language pair.
+type GlossaryDictionary struct {
+	SourceLang Language
+	TargetLang Language
+	Entries    []GlossaryEntry
+}
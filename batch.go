@@ -0,0 +1,183 @@
+package deepl
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+)
+
+const (
+	// MaxBatchTexts is the maximum number of texts that DeepL accepts in a
+	// single translation request.
+	MaxBatchTexts = 50
+
+	// MaxBatchSize is the maximum size of a translation request body in bytes.
+	MaxBatchSize = 128 * 1024
+)
+
+// BatchConcurrency returns a ClientOption that sets the maximum number of
+// concurrent requests that TranslateMany sends when it has to split the input
+// texts into multiple requests. The default is 1, which sends the requests
+// sequentially.
+func BatchConcurrency(n int) ClientOption {
+	return func(c *Client) {
+		c.batchConcurrency = n
+	}
+}
+
+// A BatchError is returned by TranslateMany if the texts were split into
+// multiple requests and at least one of them failed. The translations of the
+// successful requests are returned alongside the BatchError. It unwraps to the
+// error of the first failed text.
+type BatchError struct {
+	// Errors maps the index of every text that failed to translate to the
+	// error of the request that contained it.
+	Errors map[int]error
+}
+
+// Failed returns the indices of the texts that failed to translate in
+// ascending order.
+func (err *BatchError) Failed() []int {
+	indices := make([]int, 0, len(err.Errors))
+	for i := range err.Errors {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+func (err *BatchError) Error() string {
+	failed := err.Failed()
+	if len(failed) == 0 {
+		return "batch translation failed"
+	}
+	return fmt.Sprintf("%d texts failed to translate: %v", len(failed), err.Errors[failed[0]])
+}
+
+// Unwrap returns the error of the first failed text.
+func (err *BatchError) Unwrap() error {
+	failed := err.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return err.Errors[failed[0]]
+}
+
+type batch struct {
+	start, end int
+}
+
+// splitBatches splits the texts of treq into batches that respect MaxBatchTexts and
+// MaxBatchSize. The size of a batch is measured in the given request encoding.
+// A text that exceeds MaxBatchSize on its own is put into its own batch.
+func splitBatches(treq TranslateRequest, enc Encoding) []batch {
+	texts := treq.Texts
+	treq.Texts = nil
+	baseSize := len(treq.Values().Encode())
+	textSize := func(text string) int {
+		return len("&text=") + len(url.QueryEscape(text))
+	}
+	if enc == JSONEncoding {
+		if b, err := treq.MarshalJSON(); err == nil {
+			baseSize = len(b)
+		}
+		textSize = func(text string) int {
+			b, _ := marshalJSON(text)
+			return len(",") + len(b)
+		}
+	}
+
+	var batches []batch
+	current := batch{}
+	size := baseSize
+	for i, text := range texts {
+		n := textSize(text)
+		count := i - current.start
+		if count > 0 && (count >= MaxBatchTexts || size+n > MaxBatchSize) {
+			current.end = i
+			batches = append(batches, current)
+			current = batch{start: i}
+			size = baseSize
+		}
+		size += n
+	}
+	current.end = len(texts)
+
+	return append(batches, current)
+}
+
+func (c *Client) translateBatches(ctx context.Context, treq TranslateRequest, batches []batch) ([]Translation, error) {
+	texts := treq.Texts
+	concurrency := c.batchConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	translations := make([]Translation, len(texts))
+	var (
+		mux  sync.Mutex
+		errs = make(map[int]error)
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, concurrency)
+	)
+
+	fail := func(b batch, err error) {
+		mux.Lock()
+		defer mux.Unlock()
+		for i := b.start; i < b.end; i++ {
+			errs[i] = err
+		}
+	}
+
+	for n, b := range batches {
+		b := b
+		if err := acquire(ctx, sem); err != nil {
+			for _, b := range batches[n:] {
+				fail(b, err)
+			}
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			batchReq := treq
+			batchReq.Texts = texts[b.start:b.end]
+			result, err := c.translateBatch(ctx, batchReq)
+			if err == nil && len(result) != len(batchReq.Texts) {
+				err = fmt.Errorf("deepl responded with %d translations for %d texts", len(result), len(batchReq.Texts))
+			}
+
+			if err != nil {
+				fail(b, err)
+				return
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			copy(translations[b.start:b.end], result)
+		}()
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return translations, &BatchError{Errors: errs}
+	}
+
+	return translations, nil
+}
+
+// acquire acquires a slot of sem, unless ctx is canceled first.
+func acquire(ctx context.Context, sem chan struct{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case sem <- struct{}{}:
+		return nil
+	}
+}
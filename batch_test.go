@@ -0,0 +1,233 @@
+package deepl_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newEchoServer returns a server that "translates" texts by prefixing them
+// with the target language. Requests that contain the text "fail" fail with
+// HTTP 400. The number of texts of each request is recorded in batchSizes.
+func newEchoServer(t *testing.T, batchSizes *[]int) *httptest.Server {
+	var mux sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		texts := r.Form["text"]
+
+		mux.Lock()
+		*batchSizes = append(*batchSizes, len(texts))
+		mux.Unlock()
+
+		var response struct {
+			Translations []deepl.Translation `json:"translations"`
+		}
+		for _, text := range texts {
+			if text == "fail" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			response.Translations = append(response.Translations, deepl.Translation{
+				DetectedSourceLanguage: "EN",
+				Text:                   fmt.Sprintf("[%s] %s", r.FormValue("target_lang"), text),
+			})
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func makeTexts(n int) []string {
+	texts := make([]string, n)
+	for i := range texts {
+		texts[i] = fmt.Sprintf("Text %d", i)
+	}
+	return texts
+}
+
+func TestClient_TranslateMany_batchesByCount(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+	texts := makeTexts(120)
+
+	translations, err := client.TranslateMany(context.Background(), texts, deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{50, 50, 20}, batchSizes)
+	require.Len(t, translations, len(texts))
+	for i, text := range texts {
+		assert.Equal(t, "[DE] "+text, translations[i].Text)
+	}
+}
+
+func TestClient_TranslateMany_batchesBySize(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+	texts := []string{
+		strings.Repeat("a", 50*1024),
+		strings.Repeat("b", 50*1024),
+		strings.Repeat("c", 50*1024),
+		"d",
+	}
+
+	translations, err := client.TranslateMany(context.Background(), texts, deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2}, batchSizes)
+	require.Len(t, translations, len(texts))
+	assert.Equal(t, "[DE] d", translations[3].Text)
+}
+
+func TestClient_TranslateMany_batchesBySize_encodings(t *testing.T) {
+	for _, enc := range []deepl.Encoding{deepl.FormEncoding, deepl.JSONEncoding} {
+		t.Run(string(enc), func(t *testing.T) {
+			var bodySizes []int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				bodySizes = append(bodySizes, len(b))
+
+				var texts []string
+				if enc == deepl.JSONEncoding {
+					var body struct {
+						Text []string `json:"text"`
+					}
+					require.NoError(t, json.Unmarshal(b, &body))
+					texts = body.Text
+				} else {
+					form, err := url.ParseQuery(string(b))
+					require.NoError(t, err)
+					texts = form["text"]
+				}
+
+				var response struct {
+					Translations []deepl.Translation `json:"translations"`
+				}
+				for _, text := range texts {
+					response.Translations = append(response.Translations, deepl.Translation{Text: text})
+				}
+				json.NewEncoder(w).Encode(response)
+			}))
+			defer server.Close()
+
+			client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.RequestEncoding(enc))
+			// HTML is larger in form bodies, control characters are larger
+			// in JSON bodies.
+			texts := make([]string, 40)
+			for i := range texts {
+				texts[i] = strings.Repeat("<b>&</b>", 600)
+				if i%2 == 1 {
+					texts[i] = strings.Repeat("\x01", 4000)
+				}
+			}
+
+			translations, err := client.TranslateMany(context.Background(), texts, deepl.German, deepl.TagHandling(deepl.XMLTagHandling))
+
+			require.NoError(t, err)
+			require.Len(t, translations, len(texts))
+			assert.Equal(t, texts[39], translations[39].Text)
+			require.True(t, len(bodySizes) > 1)
+			for _, size := range bodySizes {
+				assert.True(t, size <= deepl.MaxBatchSize, "body of %d bytes exceeds MaxBatchSize", size)
+			}
+		})
+	}
+}
+
+func TestClient_TranslateMany_batchConcurrency(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.BatchConcurrency(4))
+	texts := makeTexts(333)
+
+	translations, err := client.TranslateMany(context.Background(), texts, deepl.German)
+
+	require.NoError(t, err)
+	assert.Len(t, batchSizes, 7)
+	require.Len(t, translations, len(texts))
+	for i, text := range texts {
+		assert.Equal(t, "[DE] "+text, translations[i].Text)
+	}
+}
+
+func TestClient_TranslateMany_partialFailure(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+	texts := makeTexts(120)
+	texts[60] = "fail"
+
+	translations, err := client.TranslateMany(context.Background(), texts, deepl.German)
+
+	var batchError *deepl.BatchError
+	require.True(t, errors.As(err, &batchError))
+	assert.Equal(t, makeRange(50, 100), batchError.Failed())
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+
+	require.Len(t, translations, len(texts))
+	assert.Equal(t, "[DE] Text 0", translations[0].Text)
+	assert.Equal(t, deepl.Translation{}, translations[60])
+	assert.Equal(t, "[DE] Text 119", translations[119].Text)
+}
+
+func TestClient_TranslateMany_contextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var requests int
+	httpClient := deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		treq, _ := deepl.TranslateRequestFromContext(req.Context())
+		var response struct {
+			Translations []deepl.Translation `json:"translations"`
+		}
+		for _, text := range treq.Texts {
+			response.Translations = append(response.Translations, deepl.Translation{Text: "[DE] " + text})
+		}
+		rec := httptest.NewRecorder()
+		json.NewEncoder(rec).Encode(response)
+		cancel()
+		return rec.Result(), nil
+	})
+
+	client := deepl.New("an-auth-key", deepl.HTTPClient(httpClient))
+	texts := makeTexts(150)
+
+	translations, err := client.TranslateMany(ctx, texts, deepl.German)
+
+	var batchError *deepl.BatchError
+	require.True(t, errors.As(err, &batchError))
+	assert.Equal(t, makeRange(50, 150), batchError.Failed())
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, requests, "no batches should be sent after ctx is canceled")
+	assert.Equal(t, "[DE] Text 49", translations[49].Text)
+}
+
+func makeRange(start, end int) []int {
+	r := make([]int, 0, end-start)
+	for i := start; i < end; i++ {
+		r = append(r, i)
+	}
+	return r
+}
@@ -0,0 +1,218 @@
+package deepl
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// A Cache stores translations. Use WithCache to make a Client look up
+// translations in a Cache before sending them to DeepL. Implementations must
+// be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached translation for key.
+	Get(key CacheKey) (Translation, bool)
+
+	// Set caches the translation for key.
+	Set(key CacheKey, translation Translation)
+}
+
+// A CacheKey identifies a cached translation.
+type CacheKey struct {
+	Text       string   `json:"text"`
+	TargetLang Language `json:"target_lang"`
+	SourceLang Language `json:"source_lang,omitempty"`
+
+	// Options are the remaining encoded TranslateOptions of the request,
+	// e.g. "formality=more&tag_handling=html".
+	Options string `json:"options,omitempty"`
+}
+
+// Hash returns a stable hex-encoded SHA-256 hash of k.
+func (k CacheKey) Hash() string {
+	h := sha256.New()
+	for _, part := range []string{k.Text, string(k.TargetLang), string(k.SourceLang), k.Options} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// WithCache returns a ClientOption that makes Translate, Translation and
+// TranslateMany look up translations in cache before sending the texts to
+// DeepL. Translations returned by DeepL are added to the cache. Cached
+// translations have no billed characters.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+func cacheKey(text string, treq TranslateRequest) CacheKey {
+	opts := treq.TranslateOptions
+	opts.SourceLang = ""
+	return CacheKey{
+		Text:       text,
+		TargetLang: treq.TargetLang,
+		SourceLang: treq.SourceLang,
+		Options:    opts.Values().Encode(),
+	}
+}
+
+func (c *Client) translateCached(ctx context.Context, treq TranslateRequest) ([]Translation, error) {
+	texts := treq.Texts
+	translations := make([]Translation, len(texts))
+	keys := make([]CacheKey, len(texts))
+	var (
+		misses    []int
+		missTexts []string
+	)
+	for i, text := range texts {
+		keys[i] = cacheKey(text, treq)
+		if translation, ok := c.cache.Get(keys[i]); ok {
+			translation.BilledCharacters = 0
+			translations[i] = translation
+			continue
+		}
+		misses = append(misses, i)
+		missTexts = append(missTexts, text)
+	}
+
+	if len(misses) == 0 {
+		return translations, nil
+	}
+
+	missReq := treq
+	missReq.Texts = missTexts
+	result, err := c.translate(ctx, missReq)
+
+	var batchError *BatchError
+	if err != nil && !errors.As(err, &batchError) {
+		if len(misses) == len(texts) {
+			return nil, err
+		}
+		errs := make(map[int]error, len(misses))
+		for _, i := range misses {
+			errs[i] = err
+		}
+		return translations, &BatchError{Errors: errs}
+	}
+
+	var errs map[int]error
+	for j, i := range misses {
+		if batchError != nil {
+			if err, failed := batchError.Errors[j]; failed {
+				if errs == nil {
+					errs = make(map[int]error)
+				}
+				errs[i] = err
+				continue
+			}
+		}
+		if j >= len(result) {
+			break
+		}
+		translations[i] = result[j]
+		c.cache.Set(keys[i], result[j])
+	}
+
+	if errs != nil {
+		return translations, &BatchError{Errors: errs}
+	}
+
+	return translations, nil
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used
+// translations when it is full. Use NewMemoryCache to create one.
+type MemoryCache struct {
+	size int
+	ttl  time.Duration
+
+	mux     sync.Mutex
+	entries map[CacheKey]*list.Element
+	lru     *list.List
+}
+
+type memoryCacheEntry struct {
+	key         CacheKey
+	translation Translation
+	expires     time.Time
+}
+
+// NewMemoryCache returns a MemoryCache that holds at most size translations
+// for at most ttl. A size <= 0 does not limit the number of translations and
+// a ttl <= 0 never expires them.
+func NewMemoryCache(size int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		size:    size,
+		ttl:     ttl,
+		entries: make(map[CacheKey]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// Get returns the cached translation for key.
+func (cache *MemoryCache) Get(key CacheKey) (Translation, bool) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+
+	elem, ok := cache.entries[key]
+	if !ok {
+		return Translation{}, false
+	}
+	entry := elem.Value.(*memoryCacheEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		cache.remove(elem)
+		return Translation{}, false
+	}
+	cache.lru.MoveToFront(elem)
+
+	return entry.translation, true
+}
+
+// Set caches the translation for key.
+func (cache *MemoryCache) Set(key CacheKey, translation Translation) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+
+	var expires time.Time
+	if cache.ttl > 0 {
+		expires = time.Now().Add(cache.ttl)
+	}
+
+	if elem, ok := cache.entries[key]; ok {
+		entry := elem.Value.(*memoryCacheEntry)
+		entry.translation = translation
+		entry.expires = expires
+		cache.lru.MoveToFront(elem)
+		return
+	}
+
+	cache.entries[key] = cache.lru.PushFront(&memoryCacheEntry{
+		key:         key,
+		translation: translation,
+		expires:     expires,
+	})
+
+	if cache.size > 0 && cache.lru.Len() > cache.size {
+		cache.remove(cache.lru.Back())
+	}
+}
+
+// Len returns the number of cached translations, including expired ones that
+// have not been evicted yet.
+func (cache *MemoryCache) Len() int {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	return cache.lru.Len()
+}
+
+func (cache *MemoryCache) remove(elem *list.Element) {
+	cache.lru.Remove(elem)
+	delete(cache.entries, elem.Value.(*memoryCacheEntry).key)
+}
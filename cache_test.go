@@ -0,0 +1,119 @@
+package deepl_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCache(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	cache := deepl.NewMemoryCache(0, 0)
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.WithCache(cache))
+
+	translations, err := client.TranslateMany(context.Background(), []string{"a", "b"}, deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, batchSizes)
+	assert.Equal(t, 2, cache.Len())
+
+	translations, err = client.TranslateMany(context.Background(), []string{"c", "b", "a"}, deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, batchSizes)
+	assert.Equal(t, "[DE] c", translations[0].Text)
+	assert.Equal(t, "[DE] b", translations[1].Text)
+	assert.Equal(t, "[DE] a", translations[2].Text)
+
+	text, _, err := client.Translate(context.Background(), "a", deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] a", text)
+	assert.Len(t, batchSizes, 2)
+}
+
+func TestWithCache_options(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.WithCache(deepl.NewMemoryCache(0, 0)))
+
+	calls := [][]deepl.TranslateOption{
+		nil,
+		{deepl.Formality(deepl.MoreFormal)},
+		{deepl.Formality(deepl.LessFormal)},
+		{deepl.SourceLang(deepl.English)},
+		{deepl.Formality(deepl.MoreFormal)},
+	}
+	for _, opts := range calls {
+		_, _, err := client.Translate(context.Background(), "a", deepl.German, opts...)
+		require.NoError(t, err)
+	}
+	_, _, err := client.Translate(context.Background(), "a", deepl.French)
+	require.NoError(t, err)
+
+	assert.Len(t, batchSizes, 5)
+}
+
+func TestWithCache_billedCharacters(t *testing.T) {
+	cache := deepl.NewMemoryCache(0, 0)
+	cache.Set(deepl.CacheKey{Text: "a", TargetLang: deepl.German}, deepl.Translation{Text: "[DE] a", BilledCharacters: 1})
+
+	client := deepl.New("an-auth-key", deepl.BaseURL("http://localhost:0"), deepl.WithCache(cache))
+
+	translation, err := client.Translation(context.Background(), "a", deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, deepl.Translation{Text: "[DE] a"}, translation)
+}
+
+func TestMemoryCache_size(t *testing.T) {
+	cache := deepl.NewMemoryCache(2, 0)
+	a, b, c := deepl.CacheKey{Text: "a"}, deepl.CacheKey{Text: "b"}, deepl.CacheKey{Text: "c"}
+
+	cache.Set(a, deepl.Translation{Text: "A"})
+	cache.Set(b, deepl.Translation{Text: "B"})
+	_, ok := cache.Get(a)
+	require.True(t, ok)
+	cache.Set(c, deepl.Translation{Text: "C"})
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get(b)
+	assert.False(t, ok, "least recently used translation should be evicted")
+	_, ok = cache.Get(a)
+	assert.True(t, ok)
+	_, ok = cache.Get(c)
+	assert.True(t, ok)
+}
+
+func TestMemoryCache_ttl(t *testing.T) {
+	cache := deepl.NewMemoryCache(0, 20*time.Millisecond)
+	key := deepl.CacheKey{Text: "a"}
+
+	cache.Set(key, deepl.Translation{Text: "A"})
+	translation, ok := cache.Get(key)
+	require.True(t, ok)
+	assert.Equal(t, "A", translation.Text)
+
+	time.Sleep(30 * time.Millisecond)
+
+	_, ok = cache.Get(key)
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestCacheKey_Hash(t *testing.T) {
+	key := deepl.CacheKey{Text: "a", TargetLang: deepl.German, Options: "formality=more"}
+
+	assert.Equal(t, key.Hash(), key.Hash())
+	assert.Len(t, key.Hash(), 64)
+	assert.NotEqual(t, key.Hash(), deepl.CacheKey{Text: "a", TargetLang: deepl.German}.Hash())
+	assert.NotEqual(t,
+		deepl.CacheKey{Text: "ab", TargetLang: "c"}.Hash(),
+		deepl.CacheKey{Text: "a", TargetLang: "bc"}.Hash(),
+	)
+}
@@ -7,9 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	httpi "github.com/bounoable/deepl/http"
 )
@@ -17,40 +19,166 @@ import (
 const (
 	// V2 is the base url for v2 of the deepl API.
 	V2 = "https://api.deepl.com/v2"
+
+	// V2Free is the base url for v2 of the deepl API for DeepL API Free
+	// accounts.
+	V2Free = "https://api-free.deepl.com/v2"
+
+	freeAuthKeySuffix = ":fx"
+
+	proOrigin  = "https://api.deepl.com"
+	freeOrigin = "https://api-free.deepl.com"
 )
 
 // A Client is a deepl client.
 type Client struct {
-	client       httpi.Client
-	authKey      string
-	baseURL      string
-	translateURL string
-	glossaryURL  string
+	client           httpi.Client
+	authKey          string
+	free             bool
+	baseURL          string
+	translateURL     string
+	glossaryURL      string
+	glossaryPairsURL string
+	glossaryV3URL    string
+	documentURL      string
+	usageURL         string
+	languagesURL     string
+	rephraseURL      string
+
+	documentPollMin time.Duration
+	documentPollMax time.Duration
+
+	languages *languageCache
+
+	checkGlossaryPairs bool
+
+	retry   *RetryPolicy
+	limiter *limiter
+
+	batchConcurrency int
+
+	cache Cache
+
+	defaultTranslateOptions []TranslateOption
+
+	encoding Encoding
+
+	formalityCheck FormalityCheckMode
+
+	middlewares []Middleware
+
+	logger     *slog.Logger
+	logOptions logOptions
 }
 
 // A ClientOption configures a Client.
 type ClientOption func(*Client)
 
 // A TranslateOption configures a translation request.
-type TranslateOption func(url.Values)
+type TranslateOption func(*TranslateOptions)
 
 // Error is a DeepL error.
 type Error struct {
 	// The HTTP error code, returned by the DeepL API.
 	Code int
 
+	// Message and Detail are decoded from the JSON error body, if DeepL
+	// provided one.
+	Message string
+	Detail  string
+
+	// The raw response body.
 	Body []byte
+
+	// glossary is whether the error was returned by a glossary endpoint.
+	glossary bool
 }
 
-// BaseURL returns a ClientOption that sets the base url for requests.
+// Sentinel errors that an Error can be compared against using errors.Is:
+//
+//	_, err := c.TranslateMany(context.TODO(), texts, deepl.German)
+//	if errors.Is(err, deepl.ErrQuotaExceeded) {
+//		log.Println("Quota exceeded.")
+//	}
+var (
+	// ErrBadRequest matches errors with HTTP status 400.
+	ErrBadRequest = errors.New("bad request")
+	// ErrForbidden matches errors with HTTP status 403, which DeepL returns
+	// for invalid auth keys.
+	ErrForbidden = errors.New("authorization failed")
+	// ErrNotFound matches errors with HTTP status 404.
+	ErrNotFound = errors.New("resource not found")
+	// ErrGlossaryNotFound matches errors with HTTP status 404 that were
+	// returned by a glossary endpoint.
+	ErrGlossaryNotFound = errors.New("glossary not found")
+	// ErrRequestTooLarge matches errors with HTTP status 413.
+	ErrRequestTooLarge = errors.New("request size exceeds the limit")
+	// ErrTooManyRequests matches errors with HTTP status 429.
+	ErrTooManyRequests = errors.New("too many requests")
+	// ErrQuotaExceeded matches errors with HTTP status 456.
+	ErrQuotaExceeded = errors.New("quota exceeded")
+	// ErrServiceUnavailable matches errors with HTTP status 503.
+	ErrServiceUnavailable = errors.New("service unavailable")
+)
+
+// UnsupportedGlossaryPairError is returned by CreateGlossary if the
+// GlossaryPairCheck option is used and DeepL does not support glossaries for
+// the given language pair.
+type UnsupportedGlossaryPairError struct {
+	SourceLang Language
+	TargetLang Language
+}
+
+// BaseURL returns a ClientOption that sets the base url for requests. The
+// multilingual glossary endpoints of API v3 are resolved relative to url
+// without its "/v2" suffix.
+//
+// If url points to the DeepL API Free or Pro servers, IsFreeAccount reports
+// the account type of the server. For other urls (e.g. proxies), the account
+// type is kept.
 func BaseURL(url string) ClientOption {
 	return func(c *Client) {
+		switch {
+		case hasOrigin(url, freeOrigin):
+			c.free = true
+		case hasOrigin(url, proOrigin):
+			c.free = false
+		}
 		c.baseURL = url
 		c.translateURL = fmt.Sprintf("%s/translate", c.baseURL)
 		c.glossaryURL = fmt.Sprintf("%s/glossaries", c.baseURL)
+		c.glossaryPairsURL = fmt.Sprintf("%s/glossary-language-pairs", c.baseURL)
+		c.documentURL = fmt.Sprintf("%s/document", c.baseURL)
+		c.usageURL = fmt.Sprintf("%s/usage", c.baseURL)
+		c.languagesURL = fmt.Sprintf("%s/languages", c.baseURL)
+		c.rephraseURL = fmt.Sprintf("%s/write/rephrase", c.baseURL)
+		c.glossaryV3URL = fmt.Sprintf("%s/v3/glossaries", strings.TrimSuffix(c.baseURL, "/v2"))
+	}
+}
+
+// FreeAccount returns a ClientOption that explicitly specifies whether the
+// auth key belongs to a DeepL API Free account and sets the base url to V2Free
+// or V2 respectively. Use FreeAccount if the account type cannot be detected
+// from the auth key. A custom base url that was set by BaseURL is kept.
+func FreeAccount(free bool) ClientOption {
+	return func(c *Client) {
+		c.free = free
+		if c.baseURL != "" && c.baseURL != V2 && c.baseURL != V2Free {
+			return
+		}
+		if free {
+			BaseURL(V2Free)(c)
+		} else {
+			BaseURL(V2)(c)
+		}
 	}
 }
 
+// hasOrigin returns whether url belongs to origin.
+func hasOrigin(url, origin string) bool {
+	return url == origin || strings.HasPrefix(url, origin+"/")
+}
+
 // HTTPClient returns a ClientOption that specifies the http.Client that's used
 // when making requests.
 func HTTPClient(client httpi.Client) ClientOption {
@@ -59,87 +187,147 @@ func HTTPClient(client httpi.Client) ClientOption {
 	}
 }
 
+// GlossaryPairCheck returns a ClientOption that makes CreateGlossary check
+// the source and target language against the supported glossary language
+// pairs before creating the glossary. If the pair is not supported,
+// CreateGlossary fails with an UnsupportedGlossaryPairError.
+func GlossaryPairCheck() ClientOption {
+	return func(c *Client) {
+		c.checkGlossaryPairs = true
+	}
+}
+
+// DefaultTranslateOptions returns a ClientOption that adds TranslateOptions
+// that are applied to every translation request of the Client. The default
+// options are applied before the options that are passed to Translate,
+// Translation or TranslateMany, so per-call options take precedence.
+func DefaultTranslateOptions(opts ...TranslateOption) ClientOption {
+	return func(c *Client) {
+		n := len(c.defaultTranslateOptions)
+		c.defaultTranslateOptions = append(c.defaultTranslateOptions[:n:n], opts...)
+	}
+}
+
 // SourceLang returns a ClientOption that specifies the source language of the
 // input text. If SourceLang is not used, DeepL automatically figures out the
 // source language.
 func SourceLang(lang Language) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("source_lang", string(lang))
+	return func(o *TranslateOptions) {
+		o.SourceLang = lang
 	}
 }
 
 // ShowBilledChars returns a TranslateOption that asks DeepL to return the
 // number of billed characters.
 func ShowBilledChars(show bool) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("show_billed_characters", boolString(show))
+	return func(o *TranslateOptions) {
+		o.ShowBilledCharacters = &show
 	}
 }
 
 // SplitSentences returns a TranslateOption that sets the `split_sentences`
 // DeepL option.
 func SplitSentences(split SplitSentence) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("split_sentences", split.Value())
+	return func(o *TranslateOptions) {
+		o.SplitSentences = split
 	}
 }
 
 // PreserveFormatting returns a TranslateOption that sets the
 // `preserve_formatting` DeepL option.
 func PreserveFormatting(preserve bool) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("preserve_formatting", boolString(preserve))
+	return func(o *TranslateOptions) {
+		o.PreserveFormatting = &preserve
 	}
 }
 
 // Formality returns a TranslateOption that sets the `formality` DeepL option.
 func Formality(formal Formal) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("formality", formal.Value())
+	return func(o *TranslateOptions) {
+		o.Formality = formal
+	}
+}
+
+// ModelType returns a TranslateOption that sets the `model_type` DeepL option.
+// The model that was used for a translation is reported by
+// Translation.ModelTypeUsed.
+func ModelType(model Model) TranslateOption {
+	return func(o *TranslateOptions) {
+		o.ModelType = model
 	}
 }
 
 // TagHandling returns a TranslateOption that sets the `tag_handling` DeepL
 // option.
 func TagHandling(handling TagHandlingStrategy) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("tag_handling", handling.Value())
+	return func(o *TranslateOptions) {
+		o.TagHandling = handling
 	}
 }
 
 // IgnoreTags returns a TranslateOption that sets the `ignore_tags` DeepL
 // option.
 func IgnoreTags(tags ...string) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("ignore_tags", strings.Join(tags, ","))
+	return func(o *TranslateOptions) {
+		o.IgnoreTags = tags
 	}
 }
 
-// GlossaryID returns a TranslateOption that sets the `glossary_id` DeepL
+// NonSplittingTags returns a TranslateOption that sets the
+// `non_splitting_tags` DeepL option.
+func NonSplittingTags(tags ...string) TranslateOption {
+	return func(o *TranslateOptions) {
+		o.NonSplittingTags = tags
+	}
+}
+
+// SplittingTags returns a TranslateOption that sets the `splitting_tags` DeepL
 // option.
+func SplittingTags(tags ...string) TranslateOption {
+	return func(o *TranslateOptions) {
+		o.SplittingTags = tags
+	}
+}
+
+// OutlineDetection returns a TranslateOption that sets the
+// `outline_detection` DeepL option.
+func OutlineDetection(detect bool) TranslateOption {
+	return func(o *TranslateOptions) {
+		o.OutlineDetection = &detect
+	}
+}
+
+// GlossaryID returns a TranslateOption that sets the `glossary_id` DeepL
+// option. Both glossaries and multilingual glossaries can be used.
 func GlossaryID(glossaryID string) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("glossary_id", glossaryID)
+	return func(o *TranslateOptions) {
+		o.GlossaryID = glossaryID
 	}
 }
 
 // Context returns a TranslateOption that sets the `context` DeepL
 // option.
 func Context(context string) TranslateOption {
-	return func(vals url.Values) {
-		vals.Set("context", context)
+	return func(o *TranslateOptions) {
+		o.Context = context
 	}
 }
 
 // New returns a Client that uses authKey as the DeepL authentication key.
+//
+// Auth keys of DeepL API Free accounts end with ":fx". For those keys, New
+// uses V2Free as the default base url instead of V2. Use the BaseURL or
+// FreeAccount options to override the detected endpoint.
 func New(authKey string, opts ...ClientOption) *Client {
 	c := Client{
-		authKey: authKey,
-		client:  http.DefaultClient,
+		authKey:         authKey,
+		client:          http.DefaultClient,
+		documentPollMin: defaultDocumentPollMin,
+		documentPollMax: defaultDocumentPollMax,
 	}
 
 	// default base url
-	BaseURL(V2)(&c)
+	FreeAccount(strings.HasSuffix(authKey, freeAuthKeySuffix))(&c)
 
 	for _, opt := range opts {
 		opt(&c)
@@ -148,6 +336,22 @@ func New(authKey string, opts ...ClientOption) *Client {
 	return &c
 }
 
+// With returns a copy of the Client that is additionally configured by opts.
+// The returned Client shares the http client, rate limiter and cache with c.
+// Use With to configure per-tenant defaults:
+//
+//	tenantClient := client.With(deepl.DefaultTranslateOptions(
+//		deepl.Formality(deepl.MoreFormal),
+//		deepl.GlossaryID(tenant.GlossaryID),
+//	))
+func (c *Client) With(opts ...ClientOption) *Client {
+	derived := *c
+	for _, opt := range opts {
+		opt(&derived)
+	}
+	return &derived
+}
+
 // HTTPClient returns the underlying http.Client.
 func (c *Client) HTTPClient() httpi.Client {
 	return c.client
@@ -163,6 +367,32 @@ func (c *Client) AuthKey() string {
 	return c.authKey
 }
 
+// IsFreeAccount returns whether the Client uses a DeepL API Free account. The
+// account type is detected from the ":fx" suffix of the auth key, unless it
+// is set by FreeAccount or BaseURL points to the DeepL API Free or Pro
+// servers.
+func (c *Client) IsFreeAccount() bool {
+	return c.free
+}
+
+// do sends req using the configured http client. do must be used for all
+// requests to DeepL, so that options like Retry and RateLimit apply to every
+// endpoint.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	client := c.client
+	if c.logger != nil {
+		client = &loggingClient{next: client, logger: c.logger, opts: c.logOptions, authKey: c.authKey}
+	}
+	client = c.middleware(client)
+	if c.limiter != nil {
+		client = &limitedClient{next: client, limiter: c.limiter}
+	}
+	if c.retry != nil {
+		client = &retryClient{next: client, policy: *c.retry}
+	}
+	return client.Do(req)
+}
+
 // Translate translates the provided text into the specified Language and
 // returns the translated text and the detected source Language of the input
 // text.
@@ -216,6 +446,16 @@ func (c *Client) Translation(ctx context.Context, text string, targetLang Langua
 // returns a Translation for every input text. The order of the translated texts
 // is guaranteed to be the same as the order of the input texts.
 //
+// If the texts exceed the limits of a single DeepL request (MaxBatchTexts
+// texts or MaxBatchSize bytes), TranslateMany splits them into multiple
+// requests, which are sent sequentially or concurrently (see
+// BatchConcurrency). If some of the requests fail, TranslateMany returns the
+// successful translations together with a *BatchError that reports the
+// indices of the failed texts. Translations of failed texts are zero values.
+//
+// If a Cache is configured (see WithCache), only the texts that are not
+// cached are sent to DeepL.
+//
 // When DeepL responds with an error, TranslateMany returns an Error that
 // contains the DeepL error code and message. Use errors.As to unwrap the
 // returned error into an Error:
@@ -230,33 +470,59 @@ func (c *Client) Translation(ctx context.Context, text string, targetLang Langua
 //		log.Println(fmt.Sprintf("DeepL error code %d: %s", deeplError.Code, deeplError))
 //	}
 func (c *Client) TranslateMany(ctx context.Context, texts []string, targetLang Language, opts ...TranslateOption) ([]Translation, error) {
-	vals := make(url.Values)
-	vals.Set("target_lang", string(targetLang))
+	if n := len(c.defaultTranslateOptions); n > 0 {
+		opts = append(c.defaultTranslateOptions[:n:n], opts...)
+	}
+	req := NewTranslateRequest(texts, targetLang, opts...)
 
-	for _, text := range texts {
-		vals.Add("text", text)
+	if c.formalityCheck != 0 {
+		if err := c.checkFormality(ctx, &req); err != nil {
+			return nil, fmt.Errorf("check formality: %w", err)
+		}
 	}
 
-	for _, opt := range opts {
-		opt(vals)
+	if c.cache != nil {
+		return c.translateCached(ctx, req)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.translateURL, strings.NewReader(vals.Encode()))
+	return c.translate(ctx, req)
+}
+
+func (c *Client) translate(ctx context.Context, treq TranslateRequest) ([]Translation, error) {
+	batches := splitBatches(treq, c.encoding)
+	if len(batches) <= 1 {
+		return c.translateBatch(ctx, treq)
+	}
+
+	return c.translateBatches(ctx, treq, batches)
+}
+
+func (c *Client) translateBatch(ctx context.Context, treq TranslateRequest) ([]Translation, error) {
+	body, contentType := treq.Values().Encode(), "application/x-www-form-urlencoded"
+	if c.encoding == JSONEncoding {
+		b, err := treq.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+		body, contentType = string(b), "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(withTranslateRequest(ctx, treq), "POST", c.translateURL, strings.NewReader(body))
 	if err != nil {
 		return nil, fmt.Errorf("build request: %w", err)
 	}
 
 	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Content-Type", contentType)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, Error{Code: resp.StatusCode}
+		return nil, errorFromResp(resp)
 	}
 
 	var response translateResponse
@@ -272,41 +538,72 @@ func errorFromResp(r *http.Response) error {
 	if err != nil {
 		return fmt.Errorf("read response body: %w", err)
 	}
-	return Error{
+	deeplError := Error{
 		Code: r.StatusCode,
 		Body: b,
 	}
+
+	var body struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal(b, &body); err == nil {
+		deeplError.Message = body.Message
+		deeplError.Detail = body.Detail
+	}
+
+	return deeplError
+}
+
+// glossaryErrorFromResp is errorFromResp for responses of glossary endpoints.
+// The returned Error matches ErrGlossaryNotFound for HTTP status 404.
+func glossaryErrorFromResp(r *http.Response) error {
+	err := errorFromResp(r)
+	if deeplError, ok := err.(Error); ok {
+		deeplError.glossary = true
+		return deeplError
+	}
+	return err
 }
 
 // CreateGlossary as per
 // https://www.deepl.com/docs-api/managing-glossaries/creating-a-glossary/
+//
+// If the GlossaryPairCheck option is used, CreateGlossary returns an
+// UnsupportedGlossaryPairError if the language pair is not supported.
 func (c *Client) CreateGlossary(ctx context.Context, name string, sourceLang, targetLang Language, entries []GlossaryEntry) (*Glossary, error) {
+	if c.checkGlossaryPairs {
+		pairs, err := c.GlossaryLanguagePairs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("glossary language pairs: %w", err)
+		}
+		if !GlossaryPairSupported(pairs, sourceLang, targetLang) {
+			return nil, UnsupportedGlossaryPairError{SourceLang: sourceLang, TargetLang: targetLang}
+		}
+	}
+
 	vals := make(url.Values)
 	vals.Set("name", name)
 	vals.Set("source_lang", string(sourceLang))
 	vals.Set("target_lang", string(targetLang))
 	vals.Set("entries_format", "tsv")
-	entriesTSV := make([]string, 0, len(entries))
-	for _, entry := range entries {
-		entriesTSV = append(entriesTSV, entry.Source+"\t"+entry.Target)
-	}
-	vals.Set("entries", strings.Join(entriesTSV, "\n"))
+	vals.Set("entries", encodeGlossaryEntries(entries))
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.glossaryURL, strings.NewReader(vals.Encode()))
+	req, err := http.NewRequestWithContext(nonIdempotent(ctx), "POST", c.glossaryURL, strings.NewReader(vals.Encode()))
 	if err != nil {
 		return nil, fmt.Errorf("build request: %w", err)
 	}
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, errorFromResp(resp)
+		return nil, glossaryErrorFromResp(resp)
 	}
 
 	var response Glossary
@@ -326,14 +623,14 @@ func (c *Client) ListGlossaries(ctx context.Context) ([]Glossary, error) {
 	}
 	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errorFromResp(resp)
+		return nil, glossaryErrorFromResp(resp)
 	}
 
 	var response struct {
@@ -355,14 +652,14 @@ func (c *Client) ListGlossary(ctx context.Context, glossaryID string) (*Glossary
 	}
 	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errorFromResp(resp)
+		return nil, glossaryErrorFromResp(resp)
 	}
 
 	var response Glossary
@@ -383,14 +680,14 @@ func (c *Client) ListGlossaryEntries(ctx context.Context, glossaryID string) ([]
 	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
 	req.Header.Add("Accept", "text/tab-separated-values")
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errorFromResp(resp)
+		return nil, glossaryErrorFromResp(resp)
 	}
 
 	var entries []GlossaryEntry
@@ -422,18 +719,69 @@ func (c *Client) DeleteGlossary(ctx context.Context, glossaryID string) error {
 	}
 	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
 
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("do request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent {
-		return errorFromResp(resp)
+		return glossaryErrorFromResp(resp)
 	}
 	return nil
 }
 
+// GlossaryLanguagePairs as per
+// https://www.deepl.com/docs-api/glossaries/list-glossary-languages
+func (c *Client) GlossaryLanguagePairs(ctx context.Context) ([]GlossaryLanguagePair, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.glossaryPairsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResp(resp)
+	}
+
+	var response struct {
+		SupportedLanguages []GlossaryLanguagePair `json:"supported_languages"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	return response.SupportedLanguages, nil
+}
+
+// GlossaryPairSupported returns whether pairs contains the given source and
+// target language. Language codes are compared case-insensitively and only by
+// their base language, because glossaries don't distinguish variants like
+// EN-US and EN-GB.
+func GlossaryPairSupported(pairs []GlossaryLanguagePair, sourceLang, targetLang Language) bool {
+	source, target := baseLanguage(sourceLang), baseLanguage(targetLang)
+	for _, pair := range pairs {
+		if baseLanguage(pair.SourceLang) == source && baseLanguage(pair.TargetLang) == target {
+			return true
+		}
+	}
+	return false
+}
+
+func baseLanguage(lang Language) string {
+	code := strings.ToLower(string(lang))
+	if i := strings.Index(code, "-"); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
+
 // Error returns a string representation of the DeepL error, providing details
 // based on the HTTP error code and response body.
 func (err Error) Error() string {
@@ -441,6 +789,13 @@ func (err Error) Error() string {
 	case 456:
 		return "Quota exceeded. The character limit has been reached."
 	default:
+		if err.Message != "" {
+			msg := fmt.Sprintf("%s: %s", http.StatusText(err.Code), err.Message)
+			if err.Detail != "" {
+				msg += " (" + err.Detail + ")"
+			}
+			return msg
+		}
 		if len(err.Body) > 0 {
 			return fmt.Sprintf("unexpected HTTP status %s (%s)",
 				http.StatusText(err.Code),
@@ -451,6 +806,43 @@ func (err Error) Error() string {
 	}
 }
 
+// Is returns whether target is the sentinel error that corresponds to the
+// HTTP error code of err.
+func (err Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return err.Code == http.StatusBadRequest
+	case ErrForbidden:
+		return err.Code == http.StatusForbidden
+	case ErrNotFound:
+		return err.Code == http.StatusNotFound
+	case ErrGlossaryNotFound:
+		return err.Code == http.StatusNotFound && err.glossary
+	case ErrRequestTooLarge:
+		return err.Code == http.StatusRequestEntityTooLarge
+	case ErrTooManyRequests:
+		return err.Code == http.StatusTooManyRequests
+	case ErrQuotaExceeded:
+		return err.Code == 456
+	case ErrServiceUnavailable:
+		return err.Code == http.StatusServiceUnavailable
+	default:
+		return false
+	}
+}
+
+func encodeGlossaryEntries(entries []GlossaryEntry) string {
+	entriesTSV := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entriesTSV = append(entriesTSV, entry.Source+"\t"+entry.Target)
+	}
+	return strings.Join(entriesTSV, "\n")
+}
+
+func (err UnsupportedGlossaryPairError) Error() string {
+	return fmt.Sprintf("glossaries are not supported for language pair %s → %s", err.SourceLang, err.TargetLang)
+}
+
 func boolString(b bool) string {
 	if b {
 		return "1"
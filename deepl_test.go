@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/ghttp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var _ = Describe("Client.Translate", func() {
@@ -303,18 +305,18 @@ func itHandlesOptions(request *chan *http.Request, opts *[]deepl.TranslateOption
 
 func itHandlesErrors(request *chan *http.Request, mockDeeplHeader *int, resultError *error) {
 	Context("errors", func() {
-		codes := []int{
-			http.StatusBadRequest,
-			http.StatusForbidden,
-			http.StatusNotFound,
-			http.StatusRequestEntityTooLarge,
-			http.StatusTooManyRequests,
-			456, // quota exceeded. character limit reached
-			http.StatusServiceUnavailable,
+		codes := map[int]error{
+			http.StatusBadRequest:            deepl.ErrBadRequest,
+			http.StatusForbidden:             deepl.ErrForbidden,
+			http.StatusNotFound:              deepl.ErrNotFound,
+			http.StatusRequestEntityTooLarge: deepl.ErrRequestTooLarge,
+			http.StatusTooManyRequests:       deepl.ErrTooManyRequests,
+			456:                              deepl.ErrQuotaExceeded, // quota exceeded. character limit reached
+			http.StatusServiceUnavailable:    deepl.ErrServiceUnavailable,
 		}
 
-		for _, code := range codes {
-			code := code
+		for code, sentinel := range codes {
+			code, sentinel := code, sentinel
 			Describe(http.StatusText(code), func() {
 				BeforeEach(func() {
 					*mockDeeplHeader = code
@@ -327,6 +329,13 @@ func itHandlesErrors(request *chan *http.Request, mockDeeplHeader *int, resultEr
 					Ω(deeplError.Code).Should(Equal(code))
 					close(done)
 				})
+
+				It("returns an error that matches the sentinel error", func(done Done) {
+					<-*request
+					Ω(errors.Is(*resultError, sentinel)).Should(BeTrue())
+					Ω(errors.Is(*resultError, deepl.ErrGlossaryNotFound)).Should(BeFalse())
+					close(done)
+				})
 			})
 		}
 	})
@@ -376,3 +385,198 @@ func TestClient_AuthKey(t *testing.T) {
 	client := deepl.New("supersecure123")
 	assert.Equal(t, "supersecure123", client.AuthKey())
 }
+
+func TestNew_freeAccount(t *testing.T) {
+	client := deepl.New("supersecure123:fx")
+	assert.True(t, client.IsFreeAccount())
+	assert.Equal(t, deepl.V2Free, client.BaseURL())
+
+	client = deepl.New("supersecure123")
+	assert.False(t, client.IsFreeAccount())
+	assert.Equal(t, deepl.V2, client.BaseURL())
+}
+
+func TestFreeAccount(t *testing.T) {
+	client := deepl.New("supersecure123", deepl.FreeAccount(true))
+	assert.True(t, client.IsFreeAccount())
+	assert.Equal(t, deepl.V2Free, client.BaseURL())
+
+	client = deepl.New("supersecure123:fx", deepl.FreeAccount(false))
+	assert.False(t, client.IsFreeAccount())
+	assert.Equal(t, deepl.V2, client.BaseURL())
+
+	client = deepl.New("supersecure123:fx", deepl.BaseURL("base-url"))
+	assert.True(t, client.IsFreeAccount())
+	assert.Equal(t, "base-url", client.BaseURL())
+}
+
+func TestFreeAccount_baseURL(t *testing.T) {
+	client := deepl.New("supersecure123", deepl.BaseURL(deepl.V2Free))
+	assert.True(t, client.IsFreeAccount())
+
+	client = deepl.New("supersecure123:fx", deepl.BaseURL(deepl.V2))
+	assert.False(t, client.IsFreeAccount())
+
+	client = deepl.New("supersecure123", deepl.BaseURL("base-url"), deepl.FreeAccount(false))
+	assert.False(t, client.IsFreeAccount())
+	assert.Equal(t, "base-url", client.BaseURL())
+
+	client = deepl.New("supersecure123", deepl.BaseURL("base-url"), deepl.FreeAccount(true))
+	assert.True(t, client.IsFreeAccount())
+	assert.Equal(t, "base-url", client.BaseURL())
+
+	client = deepl.New("supersecure123", deepl.BaseURL(deepl.V2), deepl.FreeAccount(true))
+	assert.True(t, client.IsFreeAccount())
+	assert.Equal(t, deepl.V2Free, client.BaseURL())
+}
+
+func TestClient_TranslateMany_errorMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Value for 'target_lang' not supported.", "detail": "Use EN-US or EN-GB."}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.TranslateMany(context.Background(), []string{"Hallo"}, deepl.English)
+
+	var deeplError deepl.Error
+	require.True(t, errors.As(err, &deeplError))
+	assert.Equal(t, http.StatusBadRequest, deeplError.Code)
+	assert.Equal(t, "Value for 'target_lang' not supported.", deeplError.Message)
+	assert.Equal(t, "Use EN-US or EN-GB.", deeplError.Detail)
+	assert.Equal(t, "Bad Request: Value for 'target_lang' not supported. (Use EN-US or EN-GB.)", deeplError.Error())
+}
+
+func TestError_Error(t *testing.T) {
+	tests := map[string]struct {
+		err  deepl.Error
+		want string
+	}{
+		"quota exceeded": {
+			err:  deepl.Error{Code: 456, Message: "Quota exceeded"},
+			want: "Quota exceeded. The character limit has been reached.",
+		},
+		"message": {
+			err:  deepl.Error{Code: http.StatusForbidden, Message: "Wrong endpoint"},
+			want: "Forbidden: Wrong endpoint",
+		},
+		"raw body": {
+			err:  deepl.Error{Code: http.StatusBadGateway, Body: []byte("upstream down\n")},
+			want: "unexpected HTTP status Bad Gateway (upstream down)",
+		},
+		"no body": {
+			err:  deepl.Error{Code: http.StatusServiceUnavailable},
+			want: "unexpected HTTP status Service Unavailable",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.err.Error())
+		})
+	}
+}
+
+func TestTranslateRequestFromContext(t *testing.T) {
+	var treq deepl.TranslateRequest
+	var ok bool
+	httpClient := deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		treq, ok = deepl.TranslateRequestFromContext(req.Context())
+		rec := httptest.NewRecorder()
+		rec.WriteString(`{"translations": [{"text": "Hallo"}]}`)
+		return rec.Result(), nil
+	})
+
+	client := deepl.New("an-auth-key", deepl.HTTPClient(httpClient))
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German, deepl.Formality(deepl.MoreFormal))
+
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, deepl.NewTranslateRequest([]string{"Hello"}, deepl.German, deepl.Formality(deepl.MoreFormal)), treq)
+}
+
+func newFormRecorder(t *testing.T, forms *[]url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		*forms = append(*forms, r.PostForm)
+		w.Write([]byte(`{"translations": [{"text": "Hallo"}]}`))
+	}))
+}
+
+func TestDefaultTranslateOptions(t *testing.T) {
+	var forms []url.Values
+	server := newFormRecorder(t, &forms)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.DefaultTranslateOptions(
+			deepl.Formality(deepl.MoreFormal),
+			deepl.TagHandling(deepl.HTMLTagHandling),
+		),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German, deepl.Formality(deepl.LessFormal))
+	require.NoError(t, err)
+
+	require.Len(t, forms, 2)
+	assert.Equal(t, "more", forms[0].Get("formality"))
+	assert.Equal(t, "html", forms[0].Get("tag_handling"))
+	assert.Equal(t, "less", forms[1].Get("formality"), "per-call options must take precedence")
+	assert.Equal(t, "html", forms[1].Get("tag_handling"))
+}
+
+func TestClient_With(t *testing.T) {
+	var forms []url.Values
+	server := newFormRecorder(t, &forms)
+	defer server.Close()
+
+	httpClient := server.Client()
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.HTTPClient(httpClient),
+		deepl.DefaultTranslateOptions(deepl.Formality(deepl.MoreFormal)),
+	)
+	tenantA := client.With(deepl.DefaultTranslateOptions(deepl.GlossaryID("glossary-a")))
+	tenantB := client.With(deepl.DefaultTranslateOptions(deepl.GlossaryID("glossary-b")))
+
+	assert.Same(t, httpClient, tenantA.HTTPClient())
+	assert.Equal(t, client.BaseURL(), tenantA.BaseURL())
+
+	for _, c := range []*deepl.Client{client, tenantA, tenantB} {
+		_, _, err := c.Translate(context.Background(), "Hello", deepl.German)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, forms, 3)
+	assert.Equal(t, "", forms[0].Get("glossary_id"))
+	assert.Equal(t, "glossary-a", forms[1].Get("glossary_id"))
+	assert.Equal(t, "glossary-b", forms[2].Get("glossary_id"))
+	for _, form := range forms {
+		assert.Equal(t, "more", form.Get("formality"))
+	}
+}
+
+func TestClient_TranslateMany_modelTypeUsed(t *testing.T) {
+	var forms []url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		forms = append(forms, r.PostForm)
+		w.Write([]byte(`{"translations": [{"text": "Hallo", "model_type_used": "quality_optimized"}]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	translation, err := client.Translation(context.Background(), "Hello", deepl.German, deepl.ModelType(deepl.PreferQualityOptimized))
+
+	require.NoError(t, err)
+	assert.Equal(t, "prefer_quality_optimized", forms[0].Get("model_type"))
+	assert.Equal(t, deepl.QualityOptimized, translation.ModelTypeUsed)
+}
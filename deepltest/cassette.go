@@ -0,0 +1,362 @@
+package deepltest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	httpi "github.com/bounoable/deepl/http"
+)
+
+// Redacted replaces the auth key in recorded interactions.
+const Redacted = "REDACTED"
+
+// ErrInteractionNotFound is returned by a Replayer for requests that have no
+// (unused) recorded interaction.
+var ErrInteractionNotFound = errors.New("no recorded interaction")
+
+// An Interaction is a request/response pair that is stored in a cassette.
+// A cassette is a JSONL file that contains one Interaction per line.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// A RecordedRequest is the request of an Interaction. The body is normalized,
+// so that requests with the same parameters have the same body.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// A RecordedResponse is the response of an Interaction. Bodies that are not
+// valid UTF-8 are stored base64-encoded.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+	Base64 bool        `json:"base64,omitempty"`
+}
+
+// Recorder is an httpi.Client that records the requests it sends and the
+// responses it receives to a cassette. The auth key is redacted from the
+// recorded interactions. A Recorder is safe for concurrent use by multiple
+// goroutines.
+//
+//	rec, err := deepltest.NewRecorder("testdata/translate.jsonl", http.DefaultClient)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer rec.Close()
+//	client := deepl.New(authKey, deepl.HTTPClient(rec))
+type Recorder struct {
+	next httpi.Client
+
+	mux  sync.Mutex
+	file *os.File
+}
+
+// NewRecorder returns a Recorder that sends requests using next and records
+// them to the cassette at path. An existing cassette is overwritten.
+func NewRecorder(path string, next httpi.Client) (*Recorder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("create cassette: %w", err)
+	}
+	return &Recorder{next: next, file: f}, nil
+}
+
+// Do sends the request and records the interaction.
+func (rec *Recorder) Do(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	resp, err := rec.next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response body: %w", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	authKey := strings.TrimPrefix(req.Header.Get("Authorization"), "DeepL-Auth-Key ")
+	interaction := Interaction{
+		Request: recordRequest(req, reqBody),
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: recordHeader(resp.Header),
+		},
+	}
+	if utf8.Valid(respBody) {
+		interaction.Response.Body = string(respBody)
+	} else {
+		interaction.Response.Body = base64.StdEncoding.EncodeToString(respBody)
+		interaction.Response.Base64 = true
+	}
+	interaction = redact(interaction, authKey)
+
+	b, err := json.Marshal(interaction)
+	if err != nil {
+		return nil, fmt.Errorf("encode interaction: %w", err)
+	}
+
+	rec.mux.Lock()
+	defer rec.mux.Unlock()
+	if rec.file == nil {
+		return nil, errors.New("recorder is closed")
+	}
+	if _, err := rec.file.Write(append(b, '\n')); err != nil {
+		return nil, fmt.Errorf("write interaction: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Close closes the cassette.
+func (rec *Recorder) Close() error {
+	rec.mux.Lock()
+	defer rec.mux.Unlock()
+	if rec.file == nil {
+		return nil
+	}
+	err := rec.file.Close()
+	rec.file = nil
+	return err
+}
+
+// Replayer is an httpi.Client that serves the responses of a cassette without
+// sending any requests. A request is matched by its method, path, query and
+// normalized body. Every recorded interaction is served only once, in the
+// order in which it was recorded. A Replayer is safe for concurrent use by
+// multiple goroutines.
+//
+//	replayer, err := deepltest.NewReplayer("testdata/translate.jsonl")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := deepl.New("an-auth-key", deepl.HTTPClient(replayer))
+type Replayer struct {
+	mux          sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer returns a Replayer for the cassette at path.
+func NewReplayer(path string) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open cassette: %w", err)
+	}
+	defer f.Close()
+
+	var interactions []Interaction
+	r := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		b, err := r.ReadBytes('\n')
+		if len(bytes.TrimSpace(b)) > 0 {
+			var interaction Interaction
+			if err := json.Unmarshal(b, &interaction); err != nil {
+				return nil, fmt.Errorf("decode interaction on line %d: %w", line, err)
+			}
+			interactions = append(interactions, interaction)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read cassette: %w", err)
+		}
+	}
+
+	return &Replayer{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}, nil
+}
+
+// Do returns the response of the first unused interaction that matches req.
+// If there is none, Do returns an error that wraps ErrInteractionNotFound.
+func (rp *Replayer) Do(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+	authKey := strings.TrimPrefix(req.Header.Get("Authorization"), "DeepL-Auth-Key ")
+	want := redact(Interaction{Request: recordRequest(req, body)}, authKey).Request
+
+	rp.mux.Lock()
+	defer rp.mux.Unlock()
+
+	for i, interaction := range rp.interactions {
+		if rp.used[i] || interaction.Request != want {
+			continue
+		}
+		rp.used[i] = true
+		return replayResponse(req, interaction.Response)
+	}
+
+	return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, ErrInteractionNotFound)
+}
+
+// Unused returns the recorded interactions that have not been served yet.
+func (rp *Replayer) Unused() []Interaction {
+	rp.mux.Lock()
+	defer rp.mux.Unlock()
+	var unused []Interaction
+	for i, interaction := range rp.interactions {
+		if !rp.used[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func replayResponse(req *http.Request, recorded RecordedResponse) (*http.Response, error) {
+	body := []byte(recorded.Body)
+	if recorded.Base64 {
+		var err error
+		if body, err = base64.StdEncoding.DecodeString(recorded.Body); err != nil {
+			return nil, fmt.Errorf("decode response body: %w", err)
+		}
+	}
+
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// readRequestBody returns the body of req and makes sure that the body can
+// still be read by the caller.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return ioutil.ReadAll(body)
+	}
+	b, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	return b, nil
+}
+
+func recordRequest(req *http.Request, body []byte) RecordedRequest {
+	return RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+		Body:   normalizeBody(req.Header.Get("Content-Type"), body),
+	}
+}
+
+// normalizeBody returns a canonical form of a request body: form values and
+// multipart fields are sorted by name and JSON objects by key.
+func normalizeBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		if vals, err := url.ParseQuery(string(body)); err == nil {
+			vals.Del("auth_key")
+			return vals.Encode()
+		}
+	case "application/json":
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err == nil {
+			if b, err := json.Marshal(v); err == nil {
+				return string(b)
+			}
+		}
+	case "multipart/form-data":
+		vals := make(url.Values)
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if err != nil {
+				break
+			}
+			b, _ := ioutil.ReadAll(part)
+			if part.FileName() != "" {
+				vals.Add(part.FormName()+".filename", part.FileName())
+			}
+			vals.Add(part.FormName(), string(b))
+		}
+		vals.Del("auth_key")
+		return vals.Encode()
+	}
+
+	return string(body)
+}
+
+func recordHeader(header http.Header) http.Header {
+	recorded := make(http.Header)
+	for _, key := range []string{"Content-Type", "Content-Disposition", "Retry-After"} {
+		if values, ok := header[key]; ok {
+			recorded[key] = values
+		}
+	}
+	return recorded
+}
+
+// redact replaces every occurrence of the auth key in the interaction.
+func redact(interaction Interaction, authKey string) Interaction {
+	if authKey == "" {
+		return interaction
+	}
+	replace := func(s string) string {
+		s = strings.ReplaceAll(s, authKey, Redacted)
+		return strings.ReplaceAll(s, url.QueryEscape(authKey), Redacted)
+	}
+	interaction.Request.Path = replace(interaction.Request.Path)
+	interaction.Request.Query = replace(interaction.Request.Query)
+	interaction.Request.Body = replace(interaction.Request.Body)
+	if !interaction.Response.Base64 {
+		interaction.Response.Body = replace(interaction.Response.Body)
+	}
+	return interaction
+}
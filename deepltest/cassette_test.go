@@ -0,0 +1,140 @@
+package deepltest_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/bounoable/deepl/deepltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_Replayer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepltest")
+	require.NoError(t, err)
+	defer removeAll(t, dir)
+	path := filepath.Join(dir, "cassette.jsonl")
+
+	server := deepltest.NewServer(deepltest.CharacterLimit(100))
+
+	rec, err := deepltest.NewRecorder(path, http.DefaultClient)
+	require.NoError(t, err)
+
+	client := deepl.New("a-secret-auth-key", deepl.BaseURL(server.URL+"/v2"), deepl.HTTPClient(rec))
+	run := func(client *deepl.Client) {
+		ctx := context.Background()
+
+		text, _, err := client.Translate(ctx, "Hello", deepl.German, deepl.Formality(deepl.MoreFormal), deepl.SourceLang(deepl.English))
+		require.NoError(t, err)
+		assert.Equal(t, "[DE] Hello", text)
+
+		text, _, err = client.Translate(ctx, "Hello", deepl.French)
+		require.NoError(t, err)
+		assert.Equal(t, "[FR] Hello", text)
+
+		glossary, err := client.CreateGlossary(ctx, "example", deepl.English, deepl.German, []deepl.GlossaryEntry{{Source: "Hello", Target: "Hallo"}})
+		require.NoError(t, err)
+
+		entries, err := client.ListGlossaryEntries(ctx, glossary.GlossaryID)
+		require.NoError(t, err)
+		assert.Equal(t, []deepl.GlossaryEntry{{Source: "Hello", Target: "Hallo"}}, entries)
+
+		require.NoError(t, client.DeleteGlossary(ctx, glossary.GlossaryID))
+
+		_, err = client.ListGlossary(ctx, glossary.GlossaryID)
+		assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+
+		usage, err := client.Usage(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 10, usage.CharacterCount)
+
+		langs, err := client.TargetLanguages(ctx)
+		require.NoError(t, err)
+		assert.NotEmpty(t, langs)
+	}
+
+	run(client)
+	require.NoError(t, rec.Close())
+	server.Close()
+
+	b, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "a-secret-auth-key")
+
+	replayer, err := deepltest.NewReplayer(path)
+	require.NoError(t, err)
+
+	// The server is closed, so every response must come from the cassette.
+	run(deepl.New("another-auth-key", deepl.HTTPClient(replayer), deepl.RequestEncoding(deepl.FormEncoding)))
+
+	assert.Empty(t, replayer.Unused())
+}
+
+func TestReplayer_matching(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepltest")
+	require.NoError(t, err)
+	defer removeAll(t, dir)
+	path := filepath.Join(dir, "cassette.jsonl")
+
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	rec, err := deepltest.NewRecorder(path, http.DefaultClient)
+	require.NoError(t, err)
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.HTTPClient(rec))
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German, deepl.SourceLang(deepl.English), deepl.Formality(deepl.MoreFormal))
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+
+	replayer, err := deepltest.NewReplayer(path)
+	require.NoError(t, err)
+	client = deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.HTTPClient(replayer))
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German, deepl.SourceLang(deepl.English))
+	assert.True(t, errors.Is(err, deepltest.ErrInteractionNotFound), "different parameters should not match")
+
+	// The order of the form values does not matter.
+	text, _, err := client.Translate(context.Background(), "Hello", deepl.German, deepl.Formality(deepl.MoreFormal), deepl.SourceLang(deepl.English))
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] Hello", text)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German, deepl.SourceLang(deepl.English), deepl.Formality(deepl.MoreFormal))
+	assert.True(t, errors.Is(err, deepltest.ErrInteractionNotFound), "interactions should only be replayed once")
+}
+
+func TestReplayer_json(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deepltest")
+	require.NoError(t, err)
+	defer removeAll(t, dir)
+	path := filepath.Join(dir, "cassette.jsonl")
+
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	rec, err := deepltest.NewRecorder(path, http.DefaultClient)
+	require.NoError(t, err)
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.HTTPClient(rec), deepl.RequestEncoding(deepl.JSONEncoding))
+	_, err = client.TranslateMany(context.Background(), []string{"Hello", "World"}, deepl.German)
+	require.NoError(t, err)
+	require.NoError(t, rec.Close())
+
+	replayer, err := deepltest.NewReplayer(path)
+	require.NoError(t, err)
+	client = deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.HTTPClient(replayer), deepl.RequestEncoding(deepl.JSONEncoding))
+
+	translations, err := client.TranslateMany(context.Background(), []string{"Hello", "World"}, deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] World", translations[1].Text)
+}
+
+func removeAll(t *testing.T, dir string) {
+	if err := os.RemoveAll(dir); err != nil {
+		t.Error(err)
+	}
+}
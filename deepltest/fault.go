@@ -0,0 +1,163 @@
+package deepltest
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// A Fault makes the Server misbehave for a request. A Fault either writes its
+// own response or calls next to let the Server handle the request normally.
+type Fault func(w http.ResponseWriter, r *http.Request, next http.Handler)
+
+type faultRule struct {
+	// from and to are the first and last request number the rule applies to.
+	from, to int
+	// byChars rules apply to translation requests once chars characters
+	// have been translated, regardless of the request number.
+	byChars bool
+	chars   int
+	fault   Fault
+}
+
+func (rule faultRule) matches(request, chars int, translate bool) bool {
+	if rule.byChars {
+		return translate && chars >= rule.chars
+	}
+	return request >= rule.from && request <= rule.to
+}
+
+// FailRequest makes the nth request that the Server receives fail with fault.
+// Requests are counted from 1 since the Server was started.
+func (s *Server) FailRequest(n int, fault Fault) {
+	s.addFault(faultRule{from: n, to: n, fault: fault})
+}
+
+// FailRequests makes the next count requests that the Server receives fail
+// with fault.
+func (s *Server) FailRequests(count int, fault Fault) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.faults = append(s.faults, faultRule{from: s.requests + 1, to: s.requests + count, fault: fault})
+}
+
+// FailAfterCharacters makes translation requests fail with fault once the
+// Server has translated at least n characters. With n <= 0, every translation
+// request fails.
+func (s *Server) FailAfterCharacters(n int, fault Fault) {
+	s.addFault(faultRule{byChars: true, chars: n, fault: fault})
+}
+
+// ClearFaults removes all faults from the Server.
+func (s *Server) ClearFaults() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests that the Server has received.
+func (s *Server) Requests() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.requests
+}
+
+func (s *Server) addFault(rule faultRule) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.faults = append(s.faults, rule)
+}
+
+// fault counts the request and returns the Fault of the first matching rule,
+// or nil if the request should be handled normally.
+func (s *Server) fault(r *http.Request) Fault {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.requests++
+	translate := strings.TrimPrefix(r.URL.Path, "/v2") == "/translate"
+	for _, rule := range s.faults {
+		if rule.matches(s.requests, s.characterCount, translate) {
+			return rule.fault
+		}
+	}
+	return nil
+}
+
+// Error returns a Fault that responds with the given status code and a DeepL
+// error message.
+func Error(code int, message string) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		writeError(w, code, message)
+	}
+}
+
+// TooManyRequests returns a Fault that responds with HTTP 429 and a
+// Retry-After header. The delay is rounded up to full seconds.
+func TooManyRequests(retryAfter time.Duration) Fault {
+	secs := int((retryAfter + time.Second - 1) / time.Second)
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		w.Header().Set("Retry-After", fmt.Sprint(secs))
+		writeError(w, http.StatusTooManyRequests, "Too many requests")
+	}
+}
+
+// QuotaExceeded returns a Fault that responds with HTTP 456.
+func QuotaExceeded() Fault {
+	return Error(456, "Quota exceeded")
+}
+
+// ServiceUnavailable returns a Fault that responds with HTTP 503.
+func ServiceUnavailable() Fault {
+	return Error(http.StatusServiceUnavailable, "Service unavailable")
+}
+
+// Delay returns a Fault that handles the request normally after waiting for d.
+// If the client cancels the request while waiting, no response is written.
+func Delay(d time.Duration) Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-r.Context().Done():
+		case <-timer.C:
+			next.ServeHTTP(w, r)
+		}
+	}
+}
+
+// TruncatedJSON returns a Fault that handles the request normally, but only
+// sends the first half of the response body.
+func TruncatedJSON() Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		for key, values := range rec.Header() {
+			w.Header()[key] = values
+		}
+		body := rec.Body.Bytes()
+		w.WriteHeader(rec.Code)
+		w.Write(body[:len(body)/2])
+	}
+}
+
+// ConnectionReset returns a Fault that resets the connection without sending
+// a response.
+func ConnectionReset() Fault {
+	return func(w http.ResponseWriter, r *http.Request, next http.Handler) {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			panic(http.ErrAbortHandler)
+		}
+		conn, _, err := hijacker.Hijack()
+		if err != nil {
+			panic(http.ErrAbortHandler)
+		}
+		if tcp, ok := conn.(*net.TCPConn); ok {
+			tcp.SetLinger(0)
+		}
+		conn.Close()
+	}
+}
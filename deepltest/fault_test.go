@@ -0,0 +1,178 @@
+package deepltest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	"github.com/bounoable/deepl/deepltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_FailRequest(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailRequest(2, deepltest.TooManyRequests(0))
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.True(t, errors.Is(err, deepl.ErrTooManyRequests))
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, server.Requests())
+	assert.Equal(t, 10, server.Usage().CharacterCount)
+}
+
+func TestServer_FailRequest_retry(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailRequest(1, deepltest.TooManyRequests(time.Second))
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}))
+
+	start := time.Now()
+	text, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] Hello", text)
+	assert.Equal(t, 2, server.Requests())
+	assert.True(t, time.Since(start) >= time.Second, "client should wait for the Retry-After delay")
+}
+
+func TestServer_FailRequests(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Retry(deepl.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+	}))
+
+	server.FailRequests(3, deepltest.ServiceUnavailable())
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	assert.True(t, errors.Is(err, deepl.ErrServiceUnavailable))
+	assert.Equal(t, 3, server.Requests())
+
+	server.FailRequests(2, deepltest.ServiceUnavailable())
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, server.Requests())
+}
+
+func TestServer_FailAfterCharacters(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailAfterCharacters(10, deepltest.QuotaExceeded())
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.TranslateMany(context.Background(), []string{"Hello", "World"}, deepl.German)
+	require.NoError(t, err)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.True(t, errors.Is(err, deepl.ErrQuotaExceeded))
+
+	_, err = client.Usage(context.Background())
+	assert.NoError(t, err, "only translation requests should fail")
+
+	server.ClearFaults()
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+}
+
+func TestServer_FailAfterCharacters_zero(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailAfterCharacters(0, deepltest.QuotaExceeded())
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	assert.True(t, errors.Is(err, deepl.ErrQuotaExceeded))
+	assert.Equal(t, 0, server.Usage().CharacterCount)
+}
+
+func TestDelay(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailRequests(2, deepltest.Delay(time.Second))
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, _, err := client.Translate(ctx, "Hello", deepl.German)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	start := time.Now()
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= time.Second)
+}
+
+func TestTruncatedJSON(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailRequest(1, deepltest.TruncatedJSON())
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.Error(t, err)
+	var deeplError deepl.Error
+	assert.False(t, errors.As(err, &deeplError))
+	assert.Contains(t, err.Error(), "decode deepl response")
+}
+
+func TestConnectionReset(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailRequest(1, deepltest.ConnectionReset())
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	assert.Error(t, err)
+
+	retrying := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}))
+	server.FailRequests(1, deepltest.ConnectionReset())
+
+	text, _, err := retrying.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] Hello", text)
+}
+
+func TestError(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	server.FailRequest(1, deepltest.Error(http.StatusBadGateway, "Bad gateway"))
+
+	_, _, err := deepl.New("an-auth-key", deepl.BaseURL(server.URL)).Translate(context.Background(), "Hello", deepl.German)
+
+	var deeplError deepl.Error
+	require.True(t, errors.As(err, &deeplError))
+	assert.Equal(t, http.StatusBadGateway, deeplError.Code)
+	assert.Equal(t, "Bad gateway", deeplError.Message)
+}
@@ -0,0 +1,581 @@
+// Package deepltest provides an in-process fake of the DeepL API for
+// integration tests.
+//
+// The fake Server implements the translation, glossary, usage and language
+// endpoints with in-memory state. Translations are deterministic: a text is
+// translated by replacing the glossary terms (if a glossary is used) and
+// prefixing the result with the target language, so "Hello" translated into
+// German becomes "[DE] Hello".
+//
+//	server := deepltest.NewServer(deepltest.CharacterLimit(1000))
+//	defer server.Close()
+//
+//	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+//	text, _, err := client.Translate(context.TODO(), "Hello", deepl.German)
+//	// text == "[DE] Hello"
+//
+// Faults make the Server misbehave on demand, to test how a Client handles
+// rate limits, quota errors, outages and network failures:
+//
+//	server.FailRequests(2, deepltest.ServiceUnavailable())
+//	server.FailAfterCharacters(1000, deepltest.QuotaExceeded())
+package deepltest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bounoable/deepl"
+)
+
+// DefaultSourceLanguages are the source languages that a Server supports by
+// default.
+var DefaultSourceLanguages = []deepl.LanguageInfo{
+	{Code: deepl.Bulgarian, Name: "Bulgarian"},
+	{Code: deepl.Chinese, Name: "Chinese"},
+	{Code: deepl.Czech, Name: "Czech"},
+	{Code: deepl.Danish, Name: "Danish"},
+	{Code: deepl.Dutch, Name: "Dutch"},
+	{Code: deepl.English, Name: "English"},
+	{Code: deepl.French, Name: "French"},
+	{Code: deepl.German, Name: "German"},
+	{Code: deepl.Italian, Name: "Italian"},
+	{Code: deepl.Japanese, Name: "Japanese"},
+	{Code: deepl.Polish, Name: "Polish"},
+	{Code: deepl.Portuguese, Name: "Portuguese"},
+	{Code: deepl.Russian, Name: "Russian"},
+	{Code: deepl.Spanish, Name: "Spanish"},
+}
+
+// DefaultTargetLanguages are the target languages that a Server supports by
+// default.
+var DefaultTargetLanguages = []deepl.LanguageInfo{
+	{Code: deepl.Bulgarian, Name: "Bulgarian"},
+	{Code: deepl.ChineseSimplified, Name: "Chinese (simplified)"},
+	{Code: deepl.Czech, Name: "Czech"},
+	{Code: deepl.Danish, Name: "Danish"},
+	{Code: deepl.Dutch, Name: "Dutch", SupportsFormality: true},
+	{Code: deepl.EnglishBritish, Name: "English (British)"},
+	{Code: deepl.EnglishAmerican, Name: "English (American)"},
+	{Code: deepl.French, Name: "French", SupportsFormality: true},
+	{Code: deepl.German, Name: "German", SupportsFormality: true},
+	{Code: deepl.Italian, Name: "Italian", SupportsFormality: true},
+	{Code: deepl.Japanese, Name: "Japanese", SupportsFormality: true},
+	{Code: deepl.Polish, Name: "Polish", SupportsFormality: true},
+	{Code: deepl.PortugueseBrazil, Name: "Portuguese (Brazilian)", SupportsFormality: true},
+	{Code: deepl.PortuguesePortugal, Name: "Portuguese (European)", SupportsFormality: true},
+	{Code: deepl.Russian, Name: "Russian", SupportsFormality: true},
+	{Code: deepl.Spanish, Name: "Spanish", SupportsFormality: true},
+}
+
+// Server is a fake DeepL API server. A Server is safe for concurrent use by
+// multiple goroutines.
+type Server struct {
+	*httptest.Server
+
+	authKey         string
+	characterLimit  int
+	sourceLanguages []deepl.LanguageInfo
+	targetLanguages []deepl.LanguageInfo
+
+	mux            sync.Mutex
+	characterCount int
+	glossaries     map[string]*glossary
+	nextGlossary   int
+	requests       int
+	faults         []faultRule
+}
+
+// An Option configures a Server.
+type Option func(*Server)
+
+type glossary struct {
+	deepl.Glossary
+	entries []deepl.GlossaryEntry
+}
+
+// AuthKey returns an Option that makes the Server reject requests that are
+// not authorized with the given auth key. By default, the Server accepts any
+// non-empty auth key.
+func AuthKey(key string) Option {
+	return func(s *Server) {
+		s.authKey = key
+	}
+}
+
+// CharacterLimit returns an Option that sets the character limit of the
+// account. Translations that would exceed the limit fail with a "Quota
+// exceeded" error (HTTP 456). A limit <= 0 disables the limit.
+func CharacterLimit(limit int) Option {
+	return func(s *Server) {
+		s.characterLimit = limit
+	}
+}
+
+// Languages returns an Option that sets the supported source and target
+// languages. Translations from or into other languages fail with a "Bad
+// Request" error.
+func Languages(source, target []deepl.LanguageInfo) Option {
+	return func(s *Server) {
+		s.sourceLanguages = source
+		s.targetLanguages = target
+	}
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down. Requests can be sent to the root of the
+// Server's URL or to URL+"/v2":
+//
+//	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		sourceLanguages: DefaultSourceLanguages,
+		targetLanguages: DefaultTargetLanguages,
+		glossaries:      make(map[string]*glossary),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Usage returns the current usage of the account.
+func (s *Server) Usage() deepl.Usage {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return deepl.Usage{
+		CharacterCount: s.characterCount,
+		CharacterLimit: s.characterLimit,
+	}
+}
+
+// SetCharacterCount sets the number of characters that have been translated
+// in the current billing period.
+func (s *Server) SetCharacterCount(count int) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.characterCount = count
+}
+
+// Glossaries returns the glossaries that currently exist, sorted by creation.
+func (s *Server) Glossaries() []deepl.Glossary {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.listGlossaries()
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if fault := s.fault(r); fault != nil {
+		fault(w, r, http.HandlerFunc(s.handle))
+		return
+	}
+	s.handle(w, r)
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusForbidden, "Authorization failure, check auth_key")
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/v2")
+	switch {
+	case path == "/translate":
+		s.allow(w, r, s.translate, "POST")
+	case path == "/usage":
+		s.allow(w, r, s.usage, "GET")
+	case path == "/languages":
+		s.allow(w, r, s.languages, "GET")
+	case path == "/glossary-language-pairs":
+		s.allow(w, r, s.glossaryLanguagePairs, "GET")
+	case path == "/glossaries":
+		s.allow(w, r, s.glossaryCollection, "GET", "POST")
+	case strings.HasPrefix(path, "/glossaries/"):
+		id := strings.TrimPrefix(path, "/glossaries/")
+		if strings.HasSuffix(id, "/entries") {
+			id = strings.TrimSuffix(id, "/entries")
+			s.allow(w, r, func(w http.ResponseWriter, r *http.Request) { s.glossaryEntries(w, r, id) }, "GET")
+			return
+		}
+		s.allow(w, r, func(w http.ResponseWriter, r *http.Request) { s.glossary(w, r, id) }, "GET", "DELETE")
+	default:
+		writeError(w, http.StatusNotFound, "Not found")
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	key := strings.TrimPrefix(r.Header.Get("Authorization"), "DeepL-Auth-Key ")
+	if key == "" || key == r.Header.Get("Authorization") {
+		return false
+	}
+	return s.authKey == "" || key == s.authKey
+}
+
+func (s *Server) allow(w http.ResponseWriter, r *http.Request, handler http.HandlerFunc, methods ...string) {
+	for _, method := range methods {
+		if r.Method == method {
+			handler(w, r)
+			return
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+}
+
+type translateRequest struct {
+	Text       []string `json:"text"`
+	TargetLang string   `json:"target_lang"`
+	SourceLang string   `json:"source_lang"`
+	GlossaryID string   `json:"glossary_id"`
+	Formality  string   `json:"formality"`
+	ModelType  string   `json:"model_type"`
+	ShowBilled *bool    `json:"show_billed_characters"`
+}
+
+func (s *Server) translate(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeTranslateRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(req.Text) == 0 {
+		writeError(w, http.StatusBadRequest, "Parameter 'text' not specified.")
+		return
+	}
+	target, ok := lookup(s.targetLanguages, req.TargetLang)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Value for 'target_lang' not supported.")
+		return
+	}
+	source := deepl.Language(strings.ToUpper(req.SourceLang))
+	if source != "" {
+		if _, ok := lookup(s.sourceLanguages, req.SourceLang); !ok {
+			writeError(w, http.StatusBadRequest, "Value for 'source_lang' not supported.")
+			return
+		}
+	}
+	if req.Formality != "" && req.Formality != "default" && !strings.HasPrefix(req.Formality, "prefer_") && !target.SupportsFormality {
+		writeError(w, http.StatusBadRequest, "'formality' is not supported for given 'target_lang'.")
+		return
+	}
+
+	var chars int
+	for _, text := range req.Text {
+		chars += len([]rune(text))
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var replacer *strings.Replacer
+	if req.GlossaryID != "" {
+		if source == "" {
+			writeError(w, http.StatusBadRequest, "Use of a glossary requires the source_lang parameter to be set.")
+			return
+		}
+		g, ok := s.glossaries[req.GlossaryID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "Glossary not found")
+			return
+		}
+		if !strings.EqualFold(g.SourceLang, baseLanguage(source)) || !strings.EqualFold(g.TargetLang, baseLanguage(target.Code)) {
+			writeError(w, http.StatusBadRequest, "Language pair of the glossary doesn't match the language pair of the request.")
+			return
+		}
+		replacer = g.replacer()
+	}
+
+	if s.characterLimit > 0 && s.characterCount+chars > s.characterLimit {
+		writeError(w, 456, "Quota exceeded")
+		return
+	}
+	s.characterCount += chars
+
+	detected := source
+	if detected == "" {
+		detected = deepl.English
+	}
+
+	var response struct {
+		Translations []deepl.Translation `json:"translations"`
+	}
+	for _, text := range req.Text {
+		translation := deepl.Translation{DetectedSourceLanguage: string(detected)}
+		if req.ShowBilled != nil && *req.ShowBilled {
+			translation.BilledCharacters = len([]rune(text))
+		}
+		if replacer != nil {
+			text = replacer.Replace(text)
+		}
+		translation.Text = fmt.Sprintf("[%s] %s", target.Code, text)
+		if req.ModelType != "" {
+			translation.ModelTypeUsed = deepl.QualityOptimized
+			if req.ModelType == string(deepl.LatencyOptimized) {
+				translation.ModelTypeUsed = deepl.LatencyOptimized
+			}
+		}
+		response.Translations = append(response.Translations, translation)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func decodeTranslateRequest(r *http.Request) (translateRequest, error) {
+	var req translateRequest
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, fmt.Errorf("Invalid JSON body: %v", err)
+		}
+		return req, nil
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return req, fmt.Errorf("Invalid form body: %v", err)
+	}
+	req.Text = r.PostForm["text"]
+	req.TargetLang = r.PostForm.Get("target_lang")
+	req.SourceLang = r.PostForm.Get("source_lang")
+	req.GlossaryID = r.PostForm.Get("glossary_id")
+	req.Formality = r.PostForm.Get("formality")
+	req.ModelType = r.PostForm.Get("model_type")
+	if v := r.PostForm.Get("show_billed_characters"); v != "" {
+		b := v == "1" || v == "true"
+		req.ShowBilled = &b
+	}
+	return req, nil
+}
+
+func (s *Server) usage(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.Usage())
+}
+
+func (s *Server) languages(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Query().Get("type") {
+	case "", "source":
+		langs := make([]deepl.LanguageInfo, len(s.sourceLanguages))
+		for i, lang := range s.sourceLanguages {
+			langs[i] = deepl.LanguageInfo{Code: lang.Code, Name: lang.Name}
+		}
+		writeJSON(w, http.StatusOK, langs)
+	case "target":
+		writeJSON(w, http.StatusOK, s.targetLanguages)
+	default:
+		writeError(w, http.StatusBadRequest, "Value for 'type' not supported.")
+	}
+}
+
+func (s *Server) glossaryLanguagePairs(w http.ResponseWriter, r *http.Request) {
+	var response struct {
+		SupportedLanguages []deepl.GlossaryLanguagePair `json:"supported_languages"`
+	}
+	response.SupportedLanguages = []deepl.GlossaryLanguagePair{}
+	seen := make(map[deepl.GlossaryLanguagePair]bool)
+	for _, source := range s.sourceLanguages {
+		for _, target := range s.targetLanguages {
+			pair := deepl.GlossaryLanguagePair{
+				SourceLang: deepl.Language(baseLanguage(source.Code)),
+				TargetLang: deepl.Language(baseLanguage(target.Code)),
+			}
+			if pair.SourceLang == pair.TargetLang || seen[pair] {
+				continue
+			}
+			seen[pair] = true
+			response.SupportedLanguages = append(response.SupportedLanguages, pair)
+		}
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) glossaryCollection(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		s.mux.Lock()
+		defer s.mux.Unlock()
+		writeJSON(w, http.StatusOK, struct {
+			Glossaries []deepl.Glossary `json:"glossaries"`
+		}{s.listGlossaries()})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid form body.")
+		return
+	}
+	name := r.PostForm.Get("name")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "Parameter 'name' not specified.")
+		return
+	}
+	source, ok := lookup(s.sourceLanguages, r.PostForm.Get("source_lang"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Value for 'source_lang' not supported.")
+		return
+	}
+	target, ok := lookup(s.targetLanguages, r.PostForm.Get("target_lang"))
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Value for 'target_lang' not supported.")
+		return
+	}
+
+	entries, err := parseEntries(r.PostForm.Get("entries"), r.PostForm.Get("entries_format"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.nextGlossary++
+	g := &glossary{
+		Glossary: deepl.Glossary{
+			GlossaryID:   fmt.Sprintf("%08d-0000-0000-0000-000000000000", s.nextGlossary),
+			Name:         name,
+			Ready:        true,
+			SourceLang:   baseLanguage(source.Code),
+			TargetLang:   baseLanguage(target.Code),
+			CreationTime: time.Now().UTC().Truncate(time.Millisecond),
+			EntryCount:   len(entries),
+		},
+		entries: entries,
+	}
+	s.glossaries[g.GlossaryID] = g
+
+	writeJSON(w, http.StatusCreated, g.Glossary)
+}
+
+func (s *Server) glossary(w http.ResponseWriter, r *http.Request, id string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	g, ok := s.glossaries[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+
+	if r.Method == "DELETE" {
+		delete(s.glossaries, id)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, g.Glossary)
+}
+
+func (s *Server) glossaryEntries(w http.ResponseWriter, r *http.Request, id string) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	g, ok := s.glossaries[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Glossary not found")
+		return
+	}
+
+	lines := make([]string, len(g.entries))
+	for i, entry := range g.entries {
+		lines[i] = entry.Source + "\t" + entry.Target
+	}
+
+	w.Header().Set("Content-Type", "text/tab-separated-values")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(strings.Join(lines, "\n")))
+}
+
+func (s *Server) listGlossaries() []deepl.Glossary {
+	glossaries := make([]deepl.Glossary, 0, len(s.glossaries))
+	for _, g := range s.glossaries {
+		glossaries = append(glossaries, g.Glossary)
+	}
+	sort.Slice(glossaries, func(i, j int) bool {
+		return glossaries[i].GlossaryID < glossaries[j].GlossaryID
+	})
+	return glossaries
+}
+
+// replacer returns a Replacer that replaces the source terms of the glossary
+// with their target terms, preferring longer terms.
+func (g *glossary) replacer() *strings.Replacer {
+	entries := make([]deepl.GlossaryEntry, len(g.entries))
+	copy(entries, g.entries)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return len(entries[i].Source) > len(entries[j].Source)
+	})
+
+	oldnew := make([]string, 0, 2*len(entries))
+	for _, entry := range entries {
+		oldnew = append(oldnew, entry.Source, entry.Target)
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+func parseEntries(entries, format string) ([]deepl.GlossaryEntry, error) {
+	sep := "\t"
+	switch format {
+	case "", "tsv":
+	case "csv":
+		sep = ","
+	default:
+		return nil, errors.New("Value for 'entries_format' not supported.")
+	}
+
+	var out []deepl.GlossaryEntry
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(entries, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, sep)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("Invalid glossary entry %q.", line)
+		}
+		if seen[parts[0]] {
+			return nil, fmt.Errorf("Duplicate source entry %q.", parts[0])
+		}
+		seen[parts[0]] = true
+		out = append(out, deepl.GlossaryEntry{Source: parts[0], Target: parts[1]})
+	}
+	if len(out) == 0 {
+		return nil, errors.New("Parameter 'entries' not specified.")
+	}
+	return out, nil
+}
+
+func lookup(langs []deepl.LanguageInfo, code string) (deepl.LanguageInfo, bool) {
+	if code == "" {
+		return deepl.LanguageInfo{}, false
+	}
+	return deepl.LookupLanguage(langs, deepl.Language(code))
+}
+
+// baseLanguage returns the lower-cased language code without its variant,
+// e.g. "en" for "EN-GB".
+func baseLanguage(lang deepl.Language) string {
+	code := strings.ToLower(string(lang))
+	if i := strings.Index(code, "-"); i >= 0 {
+		code = code[:i]
+	}
+	return code
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, struct {
+		Message string `json:"message"`
+	}{message})
+}
@@ -0,0 +1,185 @@
+package deepltest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/bounoable/deepl/deepltest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_translate(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	translations, err := client.TranslateMany(context.Background(), []string{"Hello", "World"}, deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.Translation{
+		{DetectedSourceLanguage: "EN", Text: "[DE] Hello"},
+		{DetectedSourceLanguage: "EN", Text: "[DE] World"},
+	}, translations)
+	assert.Equal(t, 10, server.Usage().CharacterCount)
+}
+
+func TestServer_translate_json(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.RequestEncoding(deepl.JSONEncoding))
+
+	translation, err := client.Translation(context.Background(), "Bonjour", deepl.EnglishBritish, deepl.SourceLang(deepl.French), deepl.ShowBilledChars(true))
+
+	require.NoError(t, err)
+	assert.Equal(t, deepl.Translation{DetectedSourceLanguage: "FR", Text: "[EN-GB] Bonjour", BilledCharacters: 7}, translation)
+}
+
+func TestServer_translate_badRequest(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello", "XX")
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.EnglishBritish, deepl.Formality(deepl.MoreFormal))
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+}
+
+func TestServer_authKey(t *testing.T) {
+	server := deepltest.NewServer(deepltest.AuthKey("an-auth-key"))
+	defer server.Close()
+
+	_, _, err := deepl.New("another-auth-key", deepl.BaseURL(server.URL)).Translate(context.Background(), "Hello", deepl.German)
+	assert.True(t, errors.Is(err, deepl.ErrForbidden))
+
+	_, _, err = deepl.New("an-auth-key", deepl.BaseURL(server.URL)).Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+}
+
+func TestServer_quota(t *testing.T) {
+	server := deepltest.NewServer(deepltest.CharacterLimit(12))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello World", deepl.German)
+	require.NoError(t, err)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.True(t, errors.Is(err, deepl.ErrQuotaExceeded))
+
+	usage, err := client.Usage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, deepl.Usage{CharacterCount: 11, CharacterLimit: 12}, *usage)
+	assert.Equal(t, 1, usage.Remaining())
+
+	server.SetCharacterCount(0)
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	assert.NoError(t, err)
+}
+
+func TestServer_glossaries(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.GlossaryPairCheck())
+	ctx := context.Background()
+
+	entries := []deepl.GlossaryEntry{
+		{Source: "Hello", Target: "Hallo"},
+		{Source: "Hello World", Target: "Hallo Welt"},
+	}
+	glossary, err := client.CreateGlossary(ctx, "example", deepl.English, deepl.German, entries)
+	require.NoError(t, err)
+	assert.Equal(t, "example", glossary.Name)
+	assert.Equal(t, "en", glossary.SourceLang)
+	assert.Equal(t, "de", glossary.TargetLang)
+	assert.Equal(t, 2, glossary.EntryCount)
+	assert.True(t, glossary.Ready)
+
+	glossaries, err := client.ListGlossaries(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.Glossary{*glossary}, glossaries)
+	assert.Equal(t, glossaries, server.Glossaries())
+
+	info, err := client.ListGlossary(ctx, glossary.GlossaryID)
+	require.NoError(t, err)
+	assert.Equal(t, glossary, info)
+
+	listed, err := client.ListGlossaryEntries(ctx, glossary.GlossaryID)
+	require.NoError(t, err)
+	assert.Equal(t, entries, listed)
+
+	translations, err := client.TranslateMany(ctx, []string{"Hello World", "Hello you"}, deepl.German, deepl.SourceLang(deepl.English), deepl.GlossaryID(glossary.GlossaryID))
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] Hallo Welt", translations[0].Text)
+	assert.Equal(t, "[DE] Hallo you", translations[1].Text)
+
+	_, _, err = client.Translate(ctx, "Hello", deepl.German, deepl.GlossaryID(glossary.GlossaryID))
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest), "glossaries require a source language")
+
+	_, _, err = client.Translate(ctx, "Hello", deepl.French, deepl.SourceLang(deepl.English), deepl.GlossaryID(glossary.GlossaryID))
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest), "glossary language pair must match")
+
+	require.NoError(t, client.DeleteGlossary(ctx, glossary.GlossaryID))
+
+	_, err = client.ListGlossary(ctx, glossary.GlossaryID)
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+	assert.Empty(t, server.Glossaries())
+}
+
+func TestServer_createGlossary_invalid(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.CreateGlossary(context.Background(), "example", deepl.English, deepl.German, nil)
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+
+	_, err = client.CreateGlossary(context.Background(), "example", deepl.English, deepl.German, []deepl.GlossaryEntry{
+		{Source: "Hello", Target: "Hallo"},
+		{Source: "Hello", Target: "Servus"},
+	})
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+}
+
+func TestServer_languages(t *testing.T) {
+	server := deepltest.NewServer(deepltest.Languages(
+		[]deepl.LanguageInfo{{Code: deepl.English, Name: "English"}},
+		[]deepl.LanguageInfo{{Code: deepl.German, Name: "German", SupportsFormality: true}},
+	))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	source, err := client.SourceLanguages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.LanguageInfo{{Code: deepl.English, Name: "English"}}, source)
+
+	target, err := client.TargetLanguages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.LanguageInfo{{Code: deepl.German, Name: "German", SupportsFormality: true}}, target)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.French)
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+}
+
+func TestServer_v2(t *testing.T) {
+	server := deepltest.NewServer()
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL+"/v2"))
+
+	text, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, "[DE] Hello", text)
+}
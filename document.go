@@ -0,0 +1,214 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDocumentPollMin = 500 * time.Millisecond
+	defaultDocumentPollMax = 10 * time.Second
+)
+
+// DocumentPolling returns a ClientOption that configures how TranslateDocument
+// polls the status of an uploaded document. Polling starts with the min delay
+// and doubles it after every poll until max is reached. If DeepL estimates a
+// longer remaining time, that estimate is used instead (capped at max).
+//
+// A min or max <= 0 is replaced by the default (500ms and 10s). A max below
+// min is raised to min.
+func DocumentPolling(min, max time.Duration) ClientOption {
+	if min <= 0 {
+		min = defaultDocumentPollMin
+	}
+	if max <= 0 {
+		max = defaultDocumentPollMax
+	}
+	if max < min {
+		max = min
+	}
+	return func(c *Client) {
+		c.documentPollMin = min
+		c.documentPollMax = max
+	}
+}
+
+// UploadDocument as per
+// https://www.deepl.com/docs-api/documents/translate-document
+//
+// UploadDocument uploads the document read from r for translation into
+// targetLang. The filename is used by DeepL to determine the file type. Only
+// the TranslateOptions that are supported by the document endpoint (e.g.
+// SourceLang, Formality and GlossaryID) have an effect.
+func (c *Client) UploadDocument(ctx context.Context, filename string, r io.Reader, targetLang Language, opts ...TranslateOption) (*Document, error) {
+	vals := NewTranslateOptions(opts...).Values()
+	vals.Set("target_lang", string(targetLang))
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for key, values := range vals {
+		for _, v := range values {
+			if err := mw.WriteField(key, v); err != nil {
+				return nil, fmt.Errorf("write %q field: %w", key, err)
+			}
+		}
+	}
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("create file field: %w", err)
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return nil, fmt.Errorf("copy document: %w", err)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, fmt.Errorf("close multipart writer: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(nonIdempotent(ctx), "POST", c.documentURL, &body)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Content-Type", mw.FormDataContentType())
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResp(resp)
+	}
+
+	var response Document
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DocumentStatus as per
+// https://www.deepl.com/docs-api/documents/get-document-status
+func (c *Client) DocumentStatus(ctx context.Context, doc Document) (*DocumentStatus, error) {
+	vals := make(url.Values)
+	vals.Set("document_key", doc.Key)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.documentURL+"/"+doc.ID, strings.NewReader(vals.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResp(resp)
+	}
+
+	var response DocumentStatus
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	return &response, nil
+}
+
+// DownloadDocument as per
+// https://www.deepl.com/docs-api/documents/download-translated-document
+//
+// DownloadDocument streams the translated document into w. A document can only
+// be downloaded once.
+func (c *Client) DownloadDocument(ctx context.Context, doc Document, w io.Writer) error {
+	vals := make(url.Values)
+	vals.Set("document_key", doc.Key)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.documentURL+"/"+doc.ID+"/result", strings.NewReader(vals.Encode()))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errorFromResp(resp)
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("copy document: %w", err)
+	}
+
+	return nil
+}
+
+// TranslateDocument uploads the document read from r, waits until DeepL has
+// translated it and writes the translated document into w. It blocks until
+// the translation is done, DeepL reports an error or ctx is canceled.
+//
+// The final DocumentStatus is returned, which reports the billed characters.
+// If DeepL fails to translate the document, TranslateDocument returns the
+// status together with an error that contains DeepL's error message.
+func (c *Client) TranslateDocument(ctx context.Context, filename string, r io.Reader, w io.Writer, targetLang Language, opts ...TranslateOption) (*DocumentStatus, error) {
+	doc, err := c.UploadDocument(ctx, filename, r, targetLang, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("upload document: %w", err)
+	}
+
+	delay := c.documentPollMin
+	for {
+		status, err := c.DocumentStatus(ctx, *doc)
+		if err != nil {
+			return nil, fmt.Errorf("document status: %w", err)
+		}
+
+		switch status.Status {
+		case DocumentDone:
+			if err := c.DownloadDocument(ctx, *doc, w); err != nil {
+				return status, fmt.Errorf("download document: %w", err)
+			}
+			return status, nil
+		case DocumentError:
+			return status, fmt.Errorf("translate document: %s", status.ErrorMessage)
+		}
+
+		wait := delay
+		if remaining := time.Duration(status.SecondsRemaining) * time.Second; remaining > wait {
+			wait = remaining
+		}
+		if wait > c.documentPollMax {
+			wait = c.documentPollMax
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status, ctx.Err()
+		case <-timer.C:
+		}
+
+		if delay *= 2; delay > c.documentPollMax {
+			delay = c.documentPollMax
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package deepl_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_TranslateDocument(t *testing.T) {
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/document", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "DE", r.FormValue("target_lang"))
+		assert.Equal(t, "EN", r.FormValue("source_lang"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		b, _ := ioutil.ReadAll(file)
+		assert.Equal(t, "example.txt", header.Filename)
+		assert.Equal(t, "Hello, world.", string(b))
+
+		w.Write([]byte(`{"document_id": "doc-id", "document_key": "doc-key"}`))
+	})
+	mux.HandleFunc("/document/doc-id", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "doc-key", r.FormValue("document_key"))
+		if polls++; polls < 3 {
+			w.Write([]byte(`{"document_id": "doc-id", "status": "translating", "seconds_remaining": 1}`))
+			return
+		}
+		w.Write([]byte(`{"document_id": "doc-id", "status": "done", "billed_characters": 13}`))
+	})
+	mux.HandleFunc("/document/doc-id/result", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "doc-key", r.FormValue("document_key"))
+		w.Write([]byte("Hallo, Welt."))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.DocumentPolling(time.Millisecond, 5*time.Millisecond),
+	)
+
+	var out bytes.Buffer
+	status, err := client.TranslateDocument(
+		context.Background(),
+		"example.txt",
+		strings.NewReader("Hello, world."),
+		&out,
+		deepl.German,
+		deepl.SourceLang(deepl.English),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, deepl.DocumentDone, status.Status)
+	assert.Equal(t, 13, status.BilledCharacters)
+	assert.Equal(t, "Hallo, Welt.", out.String())
+}
+
+func TestClient_TranslateDocument_error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/document", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"document_id": "doc-id", "document_key": "doc-key"}`))
+	})
+	mux.HandleFunc("/document/doc-id", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"document_id": "doc-id", "status": "error", "error_message": "Unsupported file"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	var out bytes.Buffer
+	status, err := client.TranslateDocument(context.Background(), "example.txt", strings.NewReader(""), &out, deepl.German)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported file")
+	assert.Equal(t, deepl.DocumentError, status.Status)
+	assert.Zero(t, out.Len())
+}
+
+func TestClient_TranslateDocument_contextCanceled(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/document", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"document_id": "doc-id", "document_key": "doc-key"}`))
+	})
+	mux.HandleFunc("/document/doc-id", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"document_id": "doc-id", "status": "queued"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.DocumentPolling(time.Hour, time.Hour),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	_, err := client.TranslateDocument(ctx, "example.txt", strings.NewReader(""), &out, deepl.German)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestDocumentPolling_nonPositive(t *testing.T) {
+	var polls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/document", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"document_id": "doc-id", "document_key": "doc-key"}`))
+	})
+	mux.HandleFunc("/document/doc-id", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Write([]byte(`{"document_id": "doc-id", "status": "queued"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.DocumentPolling(0, 0),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var out bytes.Buffer
+	_, err := client.TranslateDocument(ctx, "example.txt", strings.NewReader(""), &out, deepl.German)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, polls, "the default delay should be used")
+}
+
+func TestClient_UploadDocument_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.UploadDocument(context.Background(), "example.txt", strings.NewReader(""), deepl.German)
+
+	var deeplError deepl.Error
+	require.True(t, errors.As(err, &deeplError))
+	assert.Equal(t, http.StatusBadRequest, deeplError.Code)
+}
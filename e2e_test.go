@@ -2,21 +2,19 @@ package deepl_test
 
 import (
 	"context"
+	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/bounoable/deepl"
+	"github.com/bounoable/deepl/deepltest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func TestTranslate_withoutSourceLang(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test.")
-		return
-	}
-
-	client := deepl.New(getAuthKey(t), getOpts()...)
+	client := newE2EClient(t)
 
 	translated, sourceLang, err := client.Translate(
 		context.Background(),
@@ -30,12 +28,7 @@ func TestTranslate_withoutSourceLang(t *testing.T) {
 }
 
 func TestTranslate_showBilledCharacters(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test.")
-		return
-	}
-
-	client := deepl.New(getAuthKey(t), getOpts()...)
+	client := newE2EClient(t)
 
 	translations, err := client.TranslateMany(
 		context.Background(),
@@ -53,12 +46,7 @@ func TestTranslate_showBilledCharacters(t *testing.T) {
 }
 
 func TestTranslate_withSourceLang(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test.")
-		return
-	}
-
-	client := deepl.New(getAuthKey(t), getOpts()...)
+	client := newE2EClient(t)
 
 	_, sourceLang, err := client.Translate(
 		context.Background(),
@@ -75,12 +63,7 @@ func TestTranslate_withSourceLang(t *testing.T) {
 }
 
 func TestHTMLTagHandling(t *testing.T) {
-	if testing.Short() {
-		t.Skip("Skipping integration test.")
-		return
-	}
-
-	client := deepl.New(getAuthKey(t), getOpts()...)
+	client := newE2EClient(t)
 
 	res, _, err := client.Translate(
 		context.Background(),
@@ -93,6 +76,42 @@ func TestHTMLTagHandling(t *testing.T) {
 	assert.Equal(t, `<p alt="This is a test.">Dies ist ein Test.</p>`, res)
 }
 
+// newE2EClient returns the Client for an integration test. If the
+// DEEPL_RECORD environment variable is set, the requests of the test are
+// recorded to the cassette of the test. Otherwise, the cassette is replayed
+// if it exists and no DEEPL_AUTH_KEY is set, so that recorded tests also run
+// offline and in short mode.
+func newE2EClient(t *testing.T) *deepl.Client {
+	path := filepath.Join("testdata", "cassettes", t.Name()+".jsonl")
+
+	if os.Getenv("DEEPL_RECORD") != "" {
+		authKey := getAuthKey(t)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		rec, err := deepltest.NewRecorder(path, http.DefaultClient)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { rec.Close() })
+		return deepl.New(authKey, getOpts(deepl.HTTPClient(rec))...)
+	}
+
+	if _, err := os.Stat(path); err == nil && os.Getenv("DEEPL_AUTH_KEY") == "" {
+		replayer, err := deepltest.NewReplayer(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deepl.New("replayed-auth-key", getOpts(deepl.HTTPClient(replayer))...)
+	}
+
+	if testing.Short() {
+		t.Skip("Skipping integration test.")
+	}
+
+	return deepl.New(getAuthKey(t), getOpts()...)
+}
+
 func getOpts(opts ...deepl.ClientOption) []deepl.ClientOption {
 	apiEndpoint := os.Getenv("DEEPL_API_ENDPOINT")
 	ret := opts
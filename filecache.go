@@ -0,0 +1,268 @@
+package deepl
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const fileCacheName = "translations.jsonl"
+
+// FileCache is a Cache that persists translations in an append-only log file,
+// so that cached translations survive restarts. Translations are kept in
+// memory and every new translation is appended to the log. Use Compact to
+// remove superseded entries from the log.
+//
+// A FileCache is safe for concurrent use by multiple goroutines, but the
+// directory must not be shared by multiple processes.
+type FileCache struct {
+	path string
+
+	mux     sync.RWMutex
+	file    *os.File
+	entries map[string]CachedTranslation
+	lines   int
+	err     error
+}
+
+// A CachedTranslation is a translation that is stored in a FileCache.
+type CachedTranslation struct {
+	Key         CacheKey    `json:"key"`
+	Translation Translation `json:"translation"`
+}
+
+// OpenFileCache opens the FileCache in dir. The directory is created if it
+// does not exist.
+func OpenFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create cache directory: %w", err)
+	}
+
+	cache := &FileCache{
+		path:    filepath.Join(dir, fileCacheName),
+		entries: make(map[string]CachedTranslation),
+	}
+
+	if err := cache.load(); err != nil {
+		return nil, fmt.Errorf("load cache: %w", err)
+	}
+
+	f, err := os.OpenFile(cache.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open cache file: %w", err)
+	}
+	cache.file = f
+
+	return cache, nil
+}
+
+func (cache *FileCache) load() error {
+	f, err := os.Open(cache.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// offset is the end of the last complete line. A final line without a
+	// newline is the result of an interrupted write and is truncated, so that
+	// the next appended translation starts on a new line.
+	var offset int64
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			offset += int64(len(line))
+			// Lines that cannot be decoded are skipped, but counted, so that
+			// Compact removes them.
+			cache.lines++
+			var entry CachedTranslation
+			if jsonErr := json.Unmarshal(line, &entry); jsonErr == nil {
+				cache.entries[entry.Key.Hash()] = entry
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 && line[len(line)-1] != '\n' {
+				if err := os.Truncate(cache.path, offset); err != nil {
+					return fmt.Errorf("truncate interrupted write: %w", err)
+				}
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Get returns the cached translation for key.
+func (cache *FileCache) Get(key CacheKey) (Translation, bool) {
+	cache.mux.RLock()
+	defer cache.mux.RUnlock()
+	entry, ok := cache.entries[key.Hash()]
+	return entry.Translation, ok
+}
+
+// Set caches the translation for key and appends it to the log file. Write
+// errors are reported by Err.
+func (cache *FileCache) Set(key CacheKey, translation Translation) {
+	entry := CachedTranslation{Key: key, Translation: translation}
+	b, err := json.Marshal(entry)
+	if err != nil {
+		cache.setErr(fmt.Errorf("encode translation: %w", err))
+		return
+	}
+
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+
+	cache.entries[key.Hash()] = entry
+	if cache.file == nil {
+		return
+	}
+	if _, err := cache.file.Write(append(b, '\n')); err != nil {
+		cache.err = fmt.Errorf("write translation: %w", err)
+		return
+	}
+	cache.lines++
+}
+
+// Len returns the number of cached translations.
+func (cache *FileCache) Len() int {
+	cache.mux.RLock()
+	defer cache.mux.RUnlock()
+	return len(cache.entries)
+}
+
+// Export returns all cached translations, sorted by target language and text.
+func (cache *FileCache) Export() []CachedTranslation {
+	cache.mux.RLock()
+	entries := make([]CachedTranslation, 0, len(cache.entries))
+	for _, entry := range cache.entries {
+		entries = append(entries, entry)
+	}
+	cache.mux.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i].Key, entries[j].Key
+		if a.TargetLang != b.TargetLang {
+			return a.TargetLang < b.TargetLang
+		}
+		if a.Text != b.Text {
+			return a.Text < b.Text
+		}
+		if a.SourceLang != b.SourceLang {
+			return a.SourceLang < b.SourceLang
+		}
+		return a.Options < b.Options
+	})
+
+	return entries
+}
+
+// Compact rewrites the log file so that it contains every cached translation
+// exactly once. The log file is replaced atomically.
+func (cache *FileCache) Compact() error {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+
+	if cache.file == nil {
+		return errors.New("cache is closed")
+	}
+
+	if cache.lines == len(cache.entries) {
+		return nil
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(cache.path), fileCacheName+".*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod temporary file: %w", err)
+	}
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, entry := range cache.entries {
+		if err := enc.Encode(entry); err != nil {
+			tmp.Close()
+			return fmt.Errorf("encode translation: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+
+	if err := cache.file.Close(); err != nil {
+		return fmt.Errorf("close cache file: %w", err)
+	}
+	cache.file = nil
+
+	if err := os.Rename(tmp.Name(), cache.path); err != nil {
+		// Keep appending to the uncompacted log file.
+		cache.reopen()
+		return fmt.Errorf("replace cache file: %w", err)
+	}
+
+	if err := cache.reopen(); err != nil {
+		return err
+	}
+	cache.lines = len(cache.entries)
+
+	return nil
+}
+
+// reopen opens the log file for appending. If the log file cannot be opened,
+// the error is reported by Err and new translations are only kept in memory.
+func (cache *FileCache) reopen() error {
+	f, err := os.OpenFile(cache.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		cache.err = fmt.Errorf("open cache file: %w", err)
+		return cache.err
+	}
+	cache.file = f
+	return nil
+}
+
+// Err returns the last error that occurred while writing a translation to the
+// log file.
+func (cache *FileCache) Err() error {
+	cache.mux.RLock()
+	defer cache.mux.RUnlock()
+	return cache.err
+}
+
+// Close closes the log file. Translations that are added after Close are
+// only kept in memory.
+func (cache *FileCache) Close() error {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	if cache.file == nil {
+		return nil
+	}
+	err := cache.file.Close()
+	cache.file = nil
+	return err
+}
+
+func (cache *FileCache) setErr(err error) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	cache.err = err
+}
@@ -0,0 +1,222 @@
+package deepl_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "deepl-cache")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func TestFileCache_persistence(t *testing.T) {
+	dir := tempDir(t)
+	key := deepl.CacheKey{Text: "Hello", TargetLang: deepl.German, Options: "formality=more"}
+
+	cache, err := deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	cache.Set(key, deepl.Translation{DetectedSourceLanguage: "EN", Text: "Hallo"})
+	require.NoError(t, cache.Err())
+	require.NoError(t, cache.Close())
+
+	cache, err = deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	translation, ok := cache.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, deepl.Translation{DetectedSourceLanguage: "EN", Text: "Hallo"}, translation)
+
+	_, ok = cache.Get(deepl.CacheKey{Text: "Hello", TargetLang: deepl.German})
+	assert.False(t, ok)
+}
+
+func TestFileCache_withClient(t *testing.T) {
+	dir := tempDir(t)
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	for run := 0; run < 2; run++ {
+		cache, err := deepl.OpenFileCache(dir)
+		require.NoError(t, err)
+
+		client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.WithCache(cache))
+		translations, err := client.TranslateMany(context.Background(), []string{"a", "b"}, deepl.German)
+		require.NoError(t, err)
+		assert.Equal(t, "[DE] a", translations[0].Text)
+		assert.Equal(t, "[DE] b", translations[1].Text)
+
+		require.NoError(t, cache.Close())
+	}
+
+	assert.Equal(t, []int{2}, batchSizes, "second run should be served from the cache")
+}
+
+func TestFileCache_Compact(t *testing.T) {
+	dir := tempDir(t)
+	key := deepl.CacheKey{Text: "Hello", TargetLang: deepl.German}
+
+	cache, err := deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	for _, text := range []string{"Hallo", "Hallo!", "Hallo."} {
+		cache.Set(key, deepl.Translation{Text: text})
+	}
+	cache.Set(deepl.CacheKey{Text: "World", TargetLang: deepl.German}, deepl.Translation{Text: "Welt"})
+	assert.Equal(t, 4, countLines(t, dir))
+
+	require.NoError(t, cache.Compact())
+	assert.Equal(t, 2, countLines(t, dir))
+
+	cache.Set(deepl.CacheKey{Text: "Bye", TargetLang: deepl.German}, deepl.Translation{Text: "Tschüss"})
+	assert.Equal(t, 3, countLines(t, dir))
+	require.NoError(t, cache.Close())
+
+	cache, err = deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+	translation, ok := cache.Get(key)
+	assert.True(t, ok)
+	assert.Equal(t, "Hallo.", translation.Text)
+	assert.Equal(t, 3, cache.Len())
+}
+
+func TestFileCache_Compact_renameError(t *testing.T) {
+	dir := tempDir(t)
+	path := filepath.Join(dir, "translations.jsonl")
+	key := deepl.CacheKey{Text: "Hello", TargetLang: deepl.German}
+
+	cache, err := deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+	cache.Set(key, deepl.Translation{Text: "Hallo"})
+	cache.Set(key, deepl.Translation{Text: "Hallo!"})
+
+	// A non-empty directory in place of the log file makes the rename fail.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "dir"), 0755))
+
+	assert.Error(t, cache.Compact())
+	assert.Error(t, cache.Err(), "failing to reopen the log file should be reported")
+
+	cache.Set(deepl.CacheKey{Text: "World", TargetLang: deepl.German}, deepl.Translation{Text: "Welt"})
+	assert.Equal(t, 2, cache.Len())
+	assert.Error(t, cache.Err())
+}
+
+func TestFileCache_Export(t *testing.T) {
+	cache, err := deepl.OpenFileCache(tempDir(t))
+	require.NoError(t, err)
+	defer cache.Close()
+
+	cache.Set(deepl.CacheKey{Text: "b", TargetLang: deepl.German}, deepl.Translation{Text: "B"})
+	cache.Set(deepl.CacheKey{Text: "a", TargetLang: deepl.German}, deepl.Translation{Text: "A"})
+	cache.Set(deepl.CacheKey{Text: "a", TargetLang: deepl.French}, deepl.Translation{Text: "À"})
+
+	assert.Equal(t, []deepl.CachedTranslation{
+		{Key: deepl.CacheKey{Text: "a", TargetLang: deepl.German}, Translation: deepl.Translation{Text: "A"}},
+		{Key: deepl.CacheKey{Text: "b", TargetLang: deepl.German}, Translation: deepl.Translation{Text: "B"}},
+		{Key: deepl.CacheKey{Text: "a", TargetLang: deepl.French}, Translation: deepl.Translation{Text: "À"}},
+	}, cache.Export())
+}
+
+func TestFileCache_truncatedLog(t *testing.T) {
+	dir := tempDir(t)
+	content := `{"key": {"text": "a", "target_lang": "DE"}, "translation": {"text": "A"}}` + "\n" + `{"key": {"text": "b", "tar`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "translations.jsonl"), []byte(content), 0644))
+
+	cache, err := deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	assert.Equal(t, 1, cache.Len())
+	translation, ok := cache.Get(deepl.CacheKey{Text: "a", TargetLang: deepl.German})
+	assert.True(t, ok)
+	assert.Equal(t, "A", translation.Text)
+}
+
+func TestFileCache_tornTail(t *testing.T) {
+	dir := tempDir(t)
+	content := `{"key": {"text": "a", "target_lang": "DE"}, "translation": {"text": "A"}}` + "\n" + `{"key": {"text": "x", "tar`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "translations.jsonl"), []byte(content), 0644))
+
+	cache, err := deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	cache.Set(deepl.CacheKey{Text: "b", TargetLang: deepl.German}, deepl.Translation{Text: "B"})
+	require.NoError(t, cache.Err())
+	require.NoError(t, cache.Close())
+
+	cache, err = deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+
+	assert.Equal(t, 2, cache.Len())
+	translation, ok := cache.Get(deepl.CacheKey{Text: "b", TargetLang: deepl.German})
+	assert.True(t, ok)
+	assert.Equal(t, "B", translation.Text)
+	assert.Equal(t, 2, countLines(t, dir))
+}
+
+func TestFileCache_Compact_invalidLines(t *testing.T) {
+	dir := tempDir(t)
+	content := `{"key": {"text": "a", "target_lang": "DE"}, "translation": {"text": "A"}}` + "\n" + "garbage\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "translations.jsonl"), []byte(content), 0644))
+
+	cache, err := deepl.OpenFileCache(dir)
+	require.NoError(t, err)
+	defer cache.Close()
+	assert.Equal(t, 1, cache.Len())
+
+	require.NoError(t, cache.Compact())
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "translations.jsonl"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "garbage")
+	assert.Equal(t, 1, countLines(t, dir))
+}
+
+func TestFileCache_concurrency(t *testing.T) {
+	cache, err := deepl.OpenFileCache(tempDir(t))
+	require.NoError(t, err)
+	defer cache.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				key := deepl.CacheKey{Text: strings.Repeat("x", j), TargetLang: deepl.German}
+				cache.Set(key, deepl.Translation{Text: "y"})
+				cache.Get(key)
+			}
+			if i == 0 {
+				assert.NoError(t, cache.Compact())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.NoError(t, cache.Err())
+	assert.Equal(t, 50, cache.Len())
+}
+
+func countLines(t *testing.T, dir string) int {
+	b, err := ioutil.ReadFile(filepath.Join(dir, "translations.jsonl"))
+	require.NoError(t, err)
+	return strings.Count(string(b), "\n")
+}
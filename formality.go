@@ -1,5 +1,11 @@
 package deepl
 
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
 const (
 	// DefaultFormal is the default formality.
 	DefaultFormal Formal = "default"
@@ -7,11 +13,39 @@ const (
 	LessFormal Formal = "less"
 	// MoreFormal means the text is written in a more formal language.
 	MoreFormal Formal = "more"
+	// PreferLessFormal uses less formal language if the target language
+	// supports formality and falls back to the default formality otherwise.
+	PreferLessFormal Formal = "prefer_less"
+	// PreferMoreFormal uses more formal language if the target language
+	// supports formality and falls back to the default formality otherwise.
+	PreferMoreFormal Formal = "prefer_more"
+)
+
+const (
+	// RejectUnsupportedFormality makes translations fail with an
+	// UnsupportedFormalityError before they are sent to DeepL.
+	RejectUnsupportedFormality FormalityCheckMode = iota + 1
+
+	// DowngradeUnsupportedFormality replaces MoreFormal and LessFormal with
+	// PreferMoreFormal and PreferLessFormal.
+	DowngradeUnsupportedFormality
 )
 
 // Formal is a formality option.
 type Formal string
 
+// FormalityCheckMode specifies how the FormalityCheck option handles
+// translations into target languages that do not support formality.
+type FormalityCheckMode int
+
+// UnsupportedFormalityError is returned by translations if the
+// FormalityCheck option is used with RejectUnsupportedFormality and the
+// target language does not support formality.
+type UnsupportedFormalityError struct {
+	TargetLang Language
+	Formality  Formal
+}
+
 // Value returns the request value for f.
 func (f Formal) Value() string {
 	return string(f)
@@ -21,3 +55,50 @@ func (f Formal) Value() string {
 func (f Formal) String() string {
 	return f.Value()
 }
+
+// FormalityCheck returns a ClientOption that checks if the target language of
+// a translation supports formality before MoreFormal or LessFormal are sent
+// to DeepL, which would otherwise fail the request. The supported target
+// languages are fetched using TargetLanguages. If no LanguageCache is
+// configured, FormalityCheck caches the target languages for one hour.
+func FormalityCheck(mode FormalityCheckMode) ClientOption {
+	return func(c *Client) {
+		c.formalityCheck = mode
+		if c.languages == nil {
+			LanguageCache(time.Hour)(c)
+		}
+	}
+}
+
+func (c *Client) checkFormality(ctx context.Context, treq *TranslateRequest) error {
+	if treq.Formality != MoreFormal && treq.Formality != LessFormal {
+		return nil
+	}
+
+	langs, err := c.TargetLanguages(ctx)
+	if err != nil {
+		return fmt.Errorf("target languages: %w", err)
+	}
+
+	lang, ok := LookupLanguage(langs, treq.TargetLang)
+	if !ok || lang.SupportsFormality {
+		return nil
+	}
+
+	switch c.formalityCheck {
+	case RejectUnsupportedFormality:
+		return UnsupportedFormalityError{TargetLang: treq.TargetLang, Formality: treq.Formality}
+	case DowngradeUnsupportedFormality:
+		if treq.Formality == MoreFormal {
+			treq.Formality = PreferMoreFormal
+		} else {
+			treq.Formality = PreferLessFormal
+		}
+	}
+
+	return nil
+}
+
+func (err UnsupportedFormalityError) Error() string {
+	return fmt.Sprintf("target language %s does not support formality %q", err.TargetLang, err.Formality)
+}
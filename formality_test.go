@@ -1,17 +1,25 @@
 package deepl_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/bounoable/deepl"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFormality_Value_String(t *testing.T) {
 	tests := map[deepl.Formal]string{
-		deepl.DefaultFormal: "default",
-		deepl.LessFormal:    "less",
-		deepl.MoreFormal:    "more",
+		deepl.DefaultFormal:    "default",
+		deepl.LessFormal:       "less",
+		deepl.MoreFormal:       "more",
+		deepl.PreferLessFormal: "prefer_less",
+		deepl.PreferMoreFormal: "prefer_more",
 	}
 
 	for f, v := range tests {
@@ -19,3 +27,94 @@ func TestFormality_Value_String(t *testing.T) {
 		assert.Equal(t, f.String(), v)
 	}
 }
+
+func newFormalityServer(t *testing.T, forms *[]url.Values, languageRequests *int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/languages", func(w http.ResponseWriter, r *http.Request) {
+		*languageRequests++
+		w.Write([]byte(`[
+			{"language": "DE", "name": "German", "supports_formality": true},
+			{"language": "EN-US", "name": "English (American)", "supports_formality": false}
+		]`))
+	})
+	mux.HandleFunc("/translate", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		*forms = append(*forms, r.PostForm)
+		w.Write([]byte(`{"translations": [{"text": "Hello"}]}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFormalityCheck_reject(t *testing.T) {
+	var forms []url.Values
+	var languageRequests int
+	server := newFormalityServer(t, &forms, &languageRequests)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.FormalityCheck(deepl.RejectUnsupportedFormality),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hallo", deepl.EnglishAmerican, deepl.Formality(deepl.MoreFormal))
+
+	var formalityError deepl.UnsupportedFormalityError
+	require.True(t, errors.As(err, &formalityError))
+	assert.Equal(t, deepl.UnsupportedFormalityError{TargetLang: deepl.EnglishAmerican, Formality: deepl.MoreFormal}, formalityError)
+	assert.Empty(t, forms)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German, deepl.Formality(deepl.MoreFormal))
+	require.NoError(t, err)
+	_, _, err = client.Translate(context.Background(), "Hallo", deepl.EnglishAmerican, deepl.Formality(deepl.PreferMoreFormal))
+	require.NoError(t, err)
+
+	require.Len(t, forms, 2)
+	assert.Equal(t, "more", forms[0].Get("formality"))
+	assert.Equal(t, "prefer_more", forms[1].Get("formality"))
+	assert.Equal(t, 1, languageRequests, "target languages should be cached")
+}
+
+func TestFormalityCheck_downgrade(t *testing.T) {
+	var forms []url.Values
+	var languageRequests int
+	server := newFormalityServer(t, &forms, &languageRequests)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.FormalityCheck(deepl.DowngradeUnsupportedFormality),
+	)
+
+	for _, lang := range []deepl.Language{deepl.EnglishAmerican, deepl.German} {
+		for _, f := range []deepl.Formal{deepl.MoreFormal, deepl.LessFormal} {
+			_, _, err := client.Translate(context.Background(), "Hallo", lang, deepl.Formality(f))
+			require.NoError(t, err)
+		}
+	}
+
+	require.Len(t, forms, 4)
+	assert.Equal(t, "prefer_more", forms[0].Get("formality"))
+	assert.Equal(t, "prefer_less", forms[1].Get("formality"))
+	assert.Equal(t, "more", forms[2].Get("formality"))
+	assert.Equal(t, "less", forms[3].Get("formality"))
+}
+
+func TestFormalityCheck_noFormality(t *testing.T) {
+	var forms []url.Values
+	var languageRequests int
+	server := newFormalityServer(t, &forms, &languageRequests)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.FormalityCheck(deepl.RejectUnsupportedFormality),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hallo", deepl.EnglishAmerican)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, languageRequests)
+}
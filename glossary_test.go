@@ -0,0 +1,123 @@
+package deepl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const glossaryPairsResponse = `{"supported_languages": [
+	{"source_lang": "de", "target_lang": "en"},
+	{"source_lang": "en", "target_lang": "de"}
+]}`
+
+func TestClient_GlossaryLanguagePairs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/glossary-language-pairs", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+		w.Write([]byte(glossaryPairsResponse))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	pairs, err := client.GlossaryLanguagePairs(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.GlossaryLanguagePair{
+		{SourceLang: "de", TargetLang: "en"},
+		{SourceLang: "en", TargetLang: "de"},
+	}, pairs)
+}
+
+func TestGlossaryPairSupported(t *testing.T) {
+	pairs := []deepl.GlossaryLanguagePair{{SourceLang: "en", TargetLang: "de"}}
+
+	assert.True(t, deepl.GlossaryPairSupported(pairs, deepl.English, deepl.German))
+	assert.True(t, deepl.GlossaryPairSupported(pairs, deepl.EnglishBritish, deepl.German))
+	assert.False(t, deepl.GlossaryPairSupported(pairs, deepl.German, deepl.English))
+	assert.False(t, deepl.GlossaryPairSupported(pairs, deepl.English, deepl.Japanese))
+}
+
+func TestClient_CreateGlossary_pairCheck(t *testing.T) {
+	var created bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/glossary-language-pairs", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(glossaryPairsResponse))
+	})
+	mux.HandleFunc("/glossaries", func(w http.ResponseWriter, r *http.Request) {
+		created = true
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"glossary_id": "glossary-id", "name": "example", "source_lang": "en", "target_lang": "de"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.GlossaryPairCheck())
+	entries := []deepl.GlossaryEntry{{Source: "Hello", Target: "Hallo"}}
+
+	_, err := client.CreateGlossary(context.Background(), "example", deepl.English, deepl.Japanese, entries)
+
+	var pairError deepl.UnsupportedGlossaryPairError
+	require.True(t, errors.As(err, &pairError))
+	assert.Equal(t, deepl.UnsupportedGlossaryPairError{SourceLang: deepl.English, TargetLang: deepl.Japanese}, pairError)
+	assert.False(t, created)
+
+	glossary, err := client.CreateGlossary(context.Background(), "example", deepl.English, deepl.German, entries)
+
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "glossary-id", glossary.GlossaryID)
+}
+
+func TestClient_glossaryNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Glossary not found"}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.ListGlossary(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+	assert.True(t, errors.Is(err, deepl.ErrNotFound))
+
+	_, err = client.ListGlossaryEntries(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+
+	err = client.DeleteGlossary(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+	assert.False(t, errors.Is(err, deepl.ErrQuotaExceeded))
+}
+
+func TestClient_glossaryNotFound_withoutRequest(t *testing.T) {
+	// Custom http clients may return responses without a Request.
+	httpClient := deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusNotFound)
+		rec.WriteString(`{"message": "Glossary not found"}`)
+		resp := rec.Result()
+		resp.Request = nil
+		return resp, nil
+	})
+
+	client := deepl.New("an-auth-key", deepl.HTTPClient(httpClient))
+
+	_, err := client.ListGlossary(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+
+	_, err = client.MultilingualGlossary(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+
+	_, err = client.Usage(context.Background())
+	assert.True(t, errors.Is(err, deepl.ErrNotFound))
+	assert.False(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+}
@@ -0,0 +1,214 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type glossaryDictionaryJSON struct {
+	SourceLang    Language `json:"source_lang"`
+	TargetLang    Language `json:"target_lang"`
+	Entries       string   `json:"entries"`
+	EntriesFormat string   `json:"entries_format"`
+}
+
+func dictionaryJSON(dict GlossaryDictionary) glossaryDictionaryJSON {
+	return glossaryDictionaryJSON{
+		SourceLang:    dict.SourceLang,
+		TargetLang:    dict.TargetLang,
+		Entries:       encodeGlossaryEntries(dict.Entries),
+		EntriesFormat: "tsv",
+	}
+}
+
+func dictionariesJSON(dicts []GlossaryDictionary) []glossaryDictionaryJSON {
+	out := make([]glossaryDictionaryJSON, len(dicts))
+	for i, dict := range dicts {
+		out[i] = dictionaryJSON(dict)
+	}
+	return out
+}
+
+// CreateMultilingualGlossary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#create-a-glossary
+func (c *Client) CreateMultilingualGlossary(ctx context.Context, name string, dictionaries []GlossaryDictionary) (*MultilingualGlossary, error) {
+	body := struct {
+		Name         string                   `json:"name"`
+		Dictionaries []glossaryDictionaryJSON `json:"dictionaries"`
+	}{
+		Name:         name,
+		Dictionaries: dictionariesJSON(dictionaries),
+	}
+
+	var response MultilingualGlossary
+	if err := c.glossaryV3Request(nonIdempotent(ctx), "POST", c.glossaryV3URL, body, http.StatusCreated, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// ListMultilingualGlossaries as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#list-all-glossaries
+func (c *Client) ListMultilingualGlossaries(ctx context.Context) ([]MultilingualGlossary, error) {
+	var response struct {
+		Glossaries []MultilingualGlossary `json:"glossaries"`
+	}
+	if err := c.glossaryV3Request(ctx, "GET", c.glossaryV3URL, nil, http.StatusOK, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Glossaries, nil
+}
+
+// MultilingualGlossary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#retrieve-glossary-details
+func (c *Client) MultilingualGlossary(ctx context.Context, glossaryID string) (*MultilingualGlossary, error) {
+	var response MultilingualGlossary
+	if err := c.glossaryV3Request(ctx, "GET", c.glossaryV3URL+"/"+glossaryID, nil, http.StatusOK, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteMultilingualGlossary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#delete-a-glossary
+func (c *Client) DeleteMultilingualGlossary(ctx context.Context, glossaryID string) error {
+	return c.glossaryV3Request(ctx, "DELETE", c.glossaryV3URL+"/"+glossaryID, nil, http.StatusNoContent, nil)
+}
+
+// MultilingualGlossaryEntries as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#retrieve-glossary-entries
+//
+// MultilingualGlossaryEntries returns the entries of the dictionary for the
+// given language pair.
+func (c *Client) MultilingualGlossaryEntries(ctx context.Context, glossaryID string, sourceLang, targetLang Language) ([]GlossaryEntry, error) {
+	query := make(url.Values)
+	query.Set("source_lang", string(sourceLang))
+	query.Set("target_lang", string(targetLang))
+
+	var response struct {
+		Dictionaries []glossaryDictionaryJSON `json:"dictionaries"`
+	}
+	if err := c.glossaryV3Request(ctx, "GET", c.glossaryV3URL+"/"+glossaryID+"/entries?"+query.Encode(), nil, http.StatusOK, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Dictionaries) == 0 {
+		return nil, nil
+	}
+
+	return decodeGlossaryEntries(response.Dictionaries[0].Entries)
+}
+
+// ReplaceGlossaryDictionary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#replaces-a-glossary-dictionary
+//
+// ReplaceGlossaryDictionary replaces all entries of the dictionary for the
+// language pair of dict. The dictionary is created if it does not exist.
+func (c *Client) ReplaceGlossaryDictionary(ctx context.Context, glossaryID string, dict GlossaryDictionary) (*GlossaryDictionaryInfo, error) {
+	var response GlossaryDictionaryInfo
+	if err := c.glossaryV3Request(ctx, "PUT", c.glossaryV3URL+"/"+glossaryID+"/dictionaries", dictionaryJSON(dict), http.StatusOK, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// PatchGlossaryDictionary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#edit-glossary-details
+//
+// PatchGlossaryDictionary merges the entries of dict into the dictionary for
+// the language pair of dict. Entries with an existing source text replace the
+// existing entries.
+func (c *Client) PatchGlossaryDictionary(ctx context.Context, glossaryID string, dict GlossaryDictionary) (*MultilingualGlossary, error) {
+	body := struct {
+		Dictionaries []glossaryDictionaryJSON `json:"dictionaries"`
+	}{
+		Dictionaries: []glossaryDictionaryJSON{dictionaryJSON(dict)},
+	}
+
+	var response MultilingualGlossary
+	if err := c.glossaryV3Request(ctx, "PATCH", c.glossaryV3URL+"/"+glossaryID, body, http.StatusOK, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// DeleteGlossaryDictionary as per
+// https://developers.deepl.com/api-reference/multilingual-glossaries#deletes-a-glossary-dictionary
+func (c *Client) DeleteGlossaryDictionary(ctx context.Context, glossaryID string, sourceLang, targetLang Language) error {
+	query := make(url.Values)
+	query.Set("source_lang", string(sourceLang))
+	query.Set("target_lang", string(targetLang))
+
+	return c.glossaryV3Request(ctx, "DELETE", c.glossaryV3URL+"/"+glossaryID+"/dictionaries?"+query.Encode(), nil, http.StatusNoContent, nil)
+}
+
+// glossaryV3Request sends a request with the JSON encoded body (if not nil)
+// and decodes the JSON response into out (if not nil).
+func (c *Client) glossaryV3Request(ctx context.Context, method, url string, body interface{}, wantStatus int, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		return glossaryErrorFromResp(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	return nil
+}
+
+func decodeGlossaryEntries(tsv string) ([]GlossaryEntry, error) {
+	var entries []GlossaryEntry
+	for _, line := range strings.Split(tsv, "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.Split(line, "\t")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected 2 tab-separated values, got %q", line)
+		}
+		entries = append(entries, GlossaryEntry{
+			Source: parts[0],
+			Target: parts[1],
+		})
+	}
+	return entries, nil
+}
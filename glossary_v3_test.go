@@ -0,0 +1,266 @@
+package deepl_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multilingualGlossaryResponse = `{
+	"glossary_id": "glossary-id",
+	"name": "example",
+	"dictionaries": [
+		{"source_lang": "en", "target_lang": "de", "entry_count": 2},
+		{"source_lang": "de", "target_lang": "en", "entry_count": 1}
+	],
+	"creation_time": "2024-01-02T15:04:05Z"
+}`
+
+func TestClient_CreateMultilingualGlossary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/v3/glossaries", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]interface{}{
+			"name": "example",
+			"dictionaries": []interface{}{
+				map[string]interface{}{
+					"source_lang":    "EN",
+					"target_lang":    "DE",
+					"entries":        "Hello\tHallo\nWorld\tWelt",
+					"entries_format": "tsv",
+				},
+				map[string]interface{}{
+					"source_lang":    "DE",
+					"target_lang":    "EN",
+					"entries":        "Hallo\tHello",
+					"entries_format": "tsv",
+				},
+			},
+		}, body)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(multilingualGlossaryResponse))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	glossary, err := client.CreateMultilingualGlossary(context.Background(), "example", []deepl.GlossaryDictionary{
+		{
+			SourceLang: deepl.English,
+			TargetLang: deepl.German,
+			Entries:    []deepl.GlossaryEntry{{Source: "Hello", Target: "Hallo"}, {Source: "World", Target: "Welt"}},
+		},
+		{
+			SourceLang: deepl.German,
+			TargetLang: deepl.English,
+			Entries:    []deepl.GlossaryEntry{{Source: "Hallo", Target: "Hello"}},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "glossary-id", glossary.GlossaryID)
+	assert.Equal(t, "example", glossary.Name)
+	assert.Equal(t, []deepl.GlossaryDictionaryInfo{
+		{SourceLang: "en", TargetLang: "de", EntryCount: 2},
+		{SourceLang: "de", TargetLang: "en", EntryCount: 1},
+	}, glossary.Dictionaries)
+	assert.Equal(t, 2024, glossary.CreationTime.Year())
+}
+
+func TestClient_ListMultilingualGlossaries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v3/glossaries", r.URL.Path)
+		w.Write([]byte(`{"glossaries": [` + multilingualGlossaryResponse + `]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	glossaries, err := client.ListMultilingualGlossaries(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, glossaries, 1)
+	assert.Equal(t, "glossary-id", glossaries[0].GlossaryID)
+	assert.Len(t, glossaries[0].Dictionaries, 2)
+}
+
+func TestClient_MultilingualGlossary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v3/glossaries/glossary-id", r.URL.Path)
+		w.Write([]byte(multilingualGlossaryResponse))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	glossary, err := client.MultilingualGlossary(context.Background(), "glossary-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, "example", glossary.Name)
+}
+
+func TestClient_MultilingualGlossaryEntries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/v3/glossaries/glossary-id/entries", r.URL.Path)
+		assert.Equal(t, "EN", r.URL.Query().Get("source_lang"))
+		assert.Equal(t, "DE", r.URL.Query().Get("target_lang"))
+		w.Write([]byte(`{"dictionaries": [{"source_lang": "en", "target_lang": "de", "entries": "Hello\tHallo\nWorld\tWelt\n", "entries_format": "tsv"}]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	entries, err := client.MultilingualGlossaryEntries(context.Background(), "glossary-id", deepl.English, deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.GlossaryEntry{
+		{Source: "Hello", Target: "Hallo"},
+		{Source: "World", Target: "Welt"},
+	}, entries)
+}
+
+func TestClient_ReplaceGlossaryDictionary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/v3/glossaries/glossary-id/dictionaries", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "EN", body["source_lang"])
+		assert.Equal(t, "FR", body["target_lang"])
+		assert.Equal(t, "Hello\tBonjour", body["entries"])
+
+		w.Write([]byte(`{"source_lang": "en", "target_lang": "fr", "entry_count": 1}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	info, err := client.ReplaceGlossaryDictionary(context.Background(), "glossary-id", deepl.GlossaryDictionary{
+		SourceLang: deepl.English,
+		TargetLang: deepl.French,
+		Entries:    []deepl.GlossaryEntry{{Source: "Hello", Target: "Bonjour"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, deepl.GlossaryDictionaryInfo{SourceLang: "en", TargetLang: "fr", EntryCount: 1}, *info)
+}
+
+func TestClient_PatchGlossaryDictionary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/v3/glossaries/glossary-id", r.URL.Path)
+
+		var body struct {
+			Dictionaries []map[string]string `json:"dictionaries"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Dictionaries, 1)
+		assert.Equal(t, "Hello\tServus", body.Dictionaries[0]["entries"])
+
+		w.Write([]byte(multilingualGlossaryResponse))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	glossary, err := client.PatchGlossaryDictionary(context.Background(), "glossary-id", deepl.GlossaryDictionary{
+		SourceLang: deepl.English,
+		TargetLang: deepl.German,
+		Entries:    []deepl.GlossaryEntry{{Source: "Hello", Target: "Servus"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "glossary-id", glossary.GlossaryID)
+}
+
+func TestClient_DeleteMultilingualGlossary(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		requests = append(requests, r.URL.String())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	require.NoError(t, client.DeleteGlossaryDictionary(context.Background(), "glossary-id", deepl.English, deepl.German))
+	require.NoError(t, client.DeleteMultilingualGlossary(context.Background(), "glossary-id"))
+
+	assert.Equal(t, []string{
+		"/v3/glossaries/glossary-id/dictionaries?source_lang=EN&target_lang=DE",
+		"/v3/glossaries/glossary-id",
+	}, requests)
+}
+
+func TestClient_multilingualGlossaryNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "Glossary not found"}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.MultilingualGlossary(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+
+	err = client.DeleteMultilingualGlossary(context.Background(), "glossary-id")
+	assert.True(t, errors.Is(err, deepl.ErrGlossaryNotFound))
+}
+
+func TestClient_multilingualGlossaryURL(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"glossaries": []}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL+"/v2"))
+
+	_, err := client.ListGlossaries(context.Background())
+	require.NoError(t, err)
+	_, err = client.ListMultilingualGlossaries(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/v2/glossaries", "/v3/glossaries"}, paths)
+}
+
+func TestTranslate_multilingualGlossary(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		form, err = url.ParseQuery(string(b))
+		require.NoError(t, err)
+		w.Write([]byte(`{"translations": [{"detected_source_language": "EN", "text": "Hallo"}]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German, deepl.SourceLang(deepl.English), deepl.GlossaryID("glossary-id"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "glossary-id", form.Get("glossary_id"))
+}
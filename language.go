@@ -1,5 +1,15 @@
 package deepl
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
 // Supported languages
 const (
 	Arabic             Language = "AR"
@@ -56,3 +66,111 @@ const (
 
 // Language is a deepl language code.
 type Language string
+
+// LanguageCache returns a ClientOption that caches the results of
+// SourceLanguages and TargetLanguages for the given duration. A ttl <= 0
+// caches the results for the lifetime of the Client.
+func LanguageCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		c.languages = &languageCache{ttl: ttl}
+	}
+}
+
+// SourceLanguages as per
+// https://www.deepl.com/docs-api/general/get-languages
+//
+// SourceLanguages returns the languages that can be used as the source
+// language of a translation.
+func (c *Client) SourceLanguages(ctx context.Context) ([]LanguageInfo, error) {
+	return c.supportedLanguages(ctx, "source")
+}
+
+// TargetLanguages as per
+// https://www.deepl.com/docs-api/general/get-languages
+//
+// TargetLanguages returns the languages that can be used as the target
+// language of a translation.
+func (c *Client) TargetLanguages(ctx context.Context) ([]LanguageInfo, error) {
+	return c.supportedLanguages(ctx, "target")
+}
+
+// LookupLanguage returns the LanguageInfo for the given language code from
+// langs. Language codes are compared case-insensitively.
+func LookupLanguage(langs []LanguageInfo, code Language) (LanguageInfo, bool) {
+	for _, lang := range langs {
+		if strings.EqualFold(string(lang.Code), string(code)) {
+			return lang, true
+		}
+	}
+	return LanguageInfo{}, false
+}
+
+func (c *Client) supportedLanguages(ctx context.Context, typ string) ([]LanguageInfo, error) {
+	if c.languages != nil {
+		if langs, ok := c.languages.get(typ); ok {
+			return langs, nil
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.languagesURL+"?type="+typ, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResp(resp)
+	}
+
+	var response []LanguageInfo
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	if c.languages != nil {
+		c.languages.set(typ, response)
+	}
+
+	return response, nil
+}
+
+type languageCache struct {
+	ttl time.Duration
+
+	mux     sync.Mutex
+	entries map[string]languageCacheEntry
+}
+
+type languageCacheEntry struct {
+	languages []LanguageInfo
+	expires   time.Time
+}
+
+func (cache *languageCache) get(typ string) ([]LanguageInfo, bool) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	entry, ok := cache.entries[typ]
+	if !ok || (!entry.expires.IsZero() && time.Now().After(entry.expires)) {
+		return nil, false
+	}
+	return append([]LanguageInfo(nil), entry.languages...), true
+}
+
+func (cache *languageCache) set(typ string, langs []LanguageInfo) {
+	cache.mux.Lock()
+	defer cache.mux.Unlock()
+	if cache.entries == nil {
+		cache.entries = make(map[string]languageCacheEntry)
+	}
+	entry := languageCacheEntry{languages: append([]LanguageInfo(nil), langs...)}
+	if cache.ttl > 0 {
+		entry.expires = time.Now().Add(cache.ttl)
+	}
+	cache.entries[typ] = entry
+}
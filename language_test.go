@@ -0,0 +1,104 @@
+package deepl_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLanguagesServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/languages", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+
+		switch r.URL.Query().Get("type") {
+		case "source":
+			w.Write([]byte(`[{"language": "EN", "name": "English"}, {"language": "DE", "name": "German"}]`))
+		case "target":
+			w.Write([]byte(`[
+				{"language": "EN-US", "name": "English (American)", "supports_formality": false},
+				{"language": "DE", "name": "German", "supports_formality": true}
+			]`))
+		default:
+			t.Errorf("unexpected type %q", r.URL.Query().Get("type"))
+		}
+	}))
+}
+
+func TestClient_SourceLanguages(t *testing.T) {
+	var requests int
+	server := newLanguagesServer(t, &requests)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	langs, err := client.SourceLanguages(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.LanguageInfo{
+		{Code: deepl.English, Name: "English"},
+		{Code: deepl.German, Name: "German"},
+	}, langs)
+}
+
+func TestClient_TargetLanguages(t *testing.T) {
+	var requests int
+	server := newLanguagesServer(t, &requests)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	langs, err := client.TargetLanguages(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []deepl.LanguageInfo{
+		{Code: deepl.EnglishAmerican, Name: "English (American)"},
+		{Code: deepl.German, Name: "German", SupportsFormality: true},
+	}, langs)
+}
+
+func TestLanguageCache(t *testing.T) {
+	var requests int
+	server := newLanguagesServer(t, &requests)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.LanguageCache(50*time.Millisecond))
+
+	for i := 0; i < 3; i++ {
+		_, err := client.TargetLanguages(context.Background())
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, requests)
+
+	_, err := client.SourceLanguages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+
+	time.Sleep(60 * time.Millisecond)
+
+	_, err = client.TargetLanguages(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, requests)
+}
+
+func TestLookupLanguage(t *testing.T) {
+	langs := []deepl.LanguageInfo{
+		{Code: deepl.EnglishAmerican, Name: "English (American)"},
+		{Code: deepl.German, Name: "German", SupportsFormality: true},
+	}
+
+	lang, ok := deepl.LookupLanguage(langs, "de")
+	assert.True(t, ok)
+	assert.Equal(t, langs[1], lang)
+
+	_, ok = deepl.LookupLanguage(langs, deepl.Japanese)
+	assert.False(t, ok)
+}
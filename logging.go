@@ -0,0 +1,148 @@
+package deepl
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	httpi "github.com/bounoable/deepl/http"
+)
+
+// A LogOption configures the logging of a Client.
+type LogOption func(*logOptions)
+
+type logOptions struct {
+	level      slog.Level
+	errorLevel slog.Level
+	texts      bool
+}
+
+// Logger returns a ClientOption that logs every request to DeepL with the
+// given logger. A log record contains the endpoint, the status code, the
+// latency and the DeepL error message of failed requests. Records of
+// translation requests additionally contain the target and source language
+// and the number of texts and characters.
+//
+// The auth key is never logged. The contents of the texts are only logged if
+// the LogTexts option is used. Every attempt of a retried request is logged.
+func Logger(logger *slog.Logger, opts ...LogOption) ClientOption {
+	options := logOptions{
+		level:      slog.LevelInfo,
+		errorLevel: slog.LevelError,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return func(c *Client) {
+		c.logger = logger
+		c.logOptions = options
+	}
+}
+
+// LogLevel returns a LogOption that sets the level of successful requests.
+// The default is slog.LevelInfo.
+func LogLevel(level slog.Level) LogOption {
+	return func(o *logOptions) {
+		o.level = level
+	}
+}
+
+// LogErrorLevel returns a LogOption that sets the level of failed requests.
+// The default is slog.LevelError.
+func LogErrorLevel(level slog.Level) LogOption {
+	return func(o *logOptions) {
+		o.errorLevel = level
+	}
+}
+
+// LogTexts returns a LogOption that adds the texts of translation requests to
+// the log records.
+func LogTexts(log bool) LogOption {
+	return func(o *logOptions) {
+		o.texts = log
+	}
+}
+
+type loggingClient struct {
+	next    httpi.Client
+	logger  *slog.Logger
+	opts    logOptions
+	authKey string
+}
+
+func (lc *loggingClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	resp, err := lc.next.Do(req)
+	latency := time.Since(start)
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("endpoint", req.URL.Path),
+	}
+	if treq, ok := TranslateRequestFromContext(ctx); ok {
+		attrs = append(attrs,
+			slog.String("target_lang", string(treq.TargetLang)),
+			slog.String("source_lang", string(treq.SourceLang)),
+			slog.Int("texts", len(treq.Texts)),
+			slog.Int("characters", treq.Characters()),
+		)
+		if lc.opts.texts {
+			attrs = append(attrs, slog.Any("text", treq.Texts))
+		}
+	}
+	attrs = append(attrs, slog.Duration("latency", latency))
+
+	level := lc.opts.level
+	switch {
+	case err != nil:
+		level = lc.opts.errorLevel
+		attrs = append(attrs, slog.String("error", lc.redact(err.Error())))
+	case resp.StatusCode >= http.StatusBadRequest:
+		level = lc.opts.errorLevel
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if msg := errorMessage(resp); msg != "" {
+			attrs = append(attrs, slog.String("error", lc.redact(msg)))
+		}
+	default:
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	}
+
+	lc.logger.LogAttrs(ctx, level, "deepl request", attrs...)
+
+	return resp, err
+}
+
+func (lc *loggingClient) redact(s string) string {
+	if lc.authKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, lc.authKey, "REDACTED")
+}
+
+// errorMessage returns the DeepL error message of resp without consuming the
+// response body.
+func errorMessage(resp *http.Response) string {
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return ""
+	}
+
+	var body struct {
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return ""
+	}
+	if body.Detail != "" {
+		return body.Message + " (" + body.Detail + ")"
+	}
+	return body.Message
+}
@@ -0,0 +1,138 @@
+package deepl_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger_translate(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := deepl.New("a-secret-auth-key", deepl.BaseURL(server.URL), deepl.Logger(newTestLogger(&buf)))
+
+	_, err := client.TranslateMany(context.Background(), []string{"Hello", "World!"}, deepl.German, deepl.SourceLang(deepl.English))
+	require.NoError(t, err)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	record := records[0]
+	assert.Equal(t, "INFO", record["level"])
+	assert.Equal(t, "deepl request", record["msg"])
+	assert.Equal(t, "POST", record["method"])
+	assert.Equal(t, "/translate", record["endpoint"])
+	assert.Equal(t, "DE", record["target_lang"])
+	assert.Equal(t, "EN", record["source_lang"])
+	assert.Equal(t, float64(2), record["texts"])
+	assert.Equal(t, float64(11), record["characters"])
+	assert.Equal(t, float64(http.StatusOK), record["status"])
+	assert.Contains(t, record, "latency")
+	assert.NotContains(t, record, "text")
+	assert.NotContains(t, buf.String(), "Hello")
+	assert.NotContains(t, buf.String(), "a-secret-auth-key")
+}
+
+func TestLogger_texts(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Logger(newTestLogger(&buf), deepl.LogTexts(true)))
+
+	_, err := client.TranslateMany(context.Background(), []string{"Hello", "World"}, deepl.German)
+	require.NoError(t, err)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, []interface{}{"Hello", "World"}, records[0]["text"])
+}
+
+func TestLogger_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "Wrong auth key a-secret-auth-key"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := deepl.New("a-secret-auth-key", deepl.BaseURL(server.URL), deepl.Logger(newTestLogger(&buf), deepl.LogErrorLevel(slog.LevelWarn)))
+
+	_, err := client.Usage(context.Background())
+
+	var deeplError deepl.Error
+	require.True(t, errors.As(err, &deeplError), "logging must not consume the response body")
+	assert.Equal(t, "Wrong auth key a-secret-auth-key", deeplError.Message)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, "/usage", records[0]["endpoint"])
+	assert.Equal(t, float64(http.StatusForbidden), records[0]["status"])
+	assert.Equal(t, "Wrong auth key REDACTED", records[0]["error"])
+	assert.NotContains(t, records[0], "target_lang")
+	assert.NotContains(t, buf.String(), "a-secret-auth-key")
+}
+
+func TestLogger_transportError(t *testing.T) {
+	httpClient := deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	})
+
+	var buf bytes.Buffer
+	client := deepl.New("an-auth-key", deepl.HTTPClient(httpClient), deepl.Logger(newTestLogger(&buf)))
+
+	_, err := client.ListGlossaries(context.Background())
+	require.Error(t, err)
+
+	records := logRecords(t, &buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "ERROR", records[0]["level"])
+	assert.Equal(t, "connection refused", records[0]["error"])
+	assert.NotContains(t, records[0], "status")
+}
+
+func TestLogLevel(t *testing.T) {
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Logger(logger, deepl.LogLevel(slog.LevelDebug)))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+
+	assert.Empty(t, buf.String(), "debug records should be filtered by the handler")
+}
@@ -0,0 +1,51 @@
+package deepl
+
+import (
+	"net/http"
+
+	httpi "github.com/bounoable/deepl/http"
+)
+
+// A Middleware wraps the http client of a Client. Middlewares can inspect and
+// modify requests and responses, e.g. to add headers, log requests or collect
+// metrics:
+//
+//	func header(key, value string) deepl.Middleware {
+//		return func(next httpi.Client) httpi.Client {
+//			return deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+//				req.Header.Set(key, value)
+//				return next.Do(req)
+//			})
+//		}
+//	}
+type Middleware func(next httpi.Client) httpi.Client
+
+// ClientFunc is an adapter to allow the use of ordinary functions as an
+// httpi.Client.
+type ClientFunc func(*http.Request) (*http.Response, error)
+
+// Do calls fn(req).
+func (fn ClientFunc) Do(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// Middlewares returns a ClientOption that adds Middlewares to the Client. The
+// first Middleware receives a request first and its response last. Multiple
+// Middlewares options append to the existing Middlewares.
+//
+// Middlewares are placed between the RateLimit and Retry options and the
+// HTTPClient, so they are called for every attempt of a retried request. The
+// Logger logs requests after they have passed all Middlewares.
+func Middlewares(mws ...Middleware) ClientOption {
+	return func(c *Client) {
+		n := len(c.middlewares)
+		c.middlewares = append(c.middlewares[:n:n], mws...)
+	}
+}
+
+func (c *Client) middleware(client httpi.Client) httpi.Client {
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		client = c.middlewares[i](client)
+	}
+	return client
+}
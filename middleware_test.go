@@ -0,0 +1,147 @@
+package deepl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	httpi "github.com/bounoable/deepl/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordingMiddleware(name string, calls *[]string) deepl.Middleware {
+	return func(next httpi.Client) httpi.Client {
+		return deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name+" request")
+			resp, err := next.Do(req)
+			*calls = append(*calls, name+" response")
+			return resp, err
+		})
+	}
+}
+
+func TestMiddlewares_order(t *testing.T) {
+	var calls []string
+	httpClient := deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "http client")
+		rec := httptest.NewRecorder()
+		rec.WriteString(`{"translations": [{"text": "Hallo"}]}`)
+		return rec.Result(), nil
+	})
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.HTTPClient(httpClient),
+		deepl.Middlewares(recordingMiddleware("a", &calls), recordingMiddleware("b", &calls)),
+		deepl.Middlewares(recordingMiddleware("c", &calls)),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"a request",
+		"b request",
+		"c request",
+		"http client",
+		"c response",
+		"b response",
+		"a response",
+	}, calls)
+}
+
+func TestMiddlewares_With(t *testing.T) {
+	var calls []string
+	var batchSizes []int
+	server := newEchoServer(t, &batchSizes)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Middlewares(recordingMiddleware("a", &calls)))
+	derived := client.With(deepl.Middlewares(recordingMiddleware("b", &calls)))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a request", "a response"}, calls)
+
+	calls = nil
+	_, _, err = derived.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a request", "b request", "b response", "a response"}, calls)
+}
+
+func TestMiddlewares_header(t *testing.T) {
+	var header string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Request-Id")
+		w.Write([]byte(`{"glossaries": []}`))
+	}))
+	defer server.Close()
+
+	setHeader := func(next httpi.Client) httpi.Client {
+		return deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-Id", "a-request-id")
+			return next.Do(req)
+		})
+	}
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.Middlewares(setHeader))
+
+	_, err := client.ListGlossaries(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "a-request-id", header)
+}
+
+func TestMiddlewares_shortCircuit(t *testing.T) {
+	errBlocked := errors.New("blocked")
+	block := func(next httpi.Client) httpi.Client {
+		return deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, errBlocked
+		})
+	}
+	httpClient := deepl.ClientFunc(func(req *http.Request) (*http.Response, error) {
+		t.Fatal("request should not be sent")
+		return nil, nil
+	})
+
+	client := deepl.New("an-auth-key", deepl.HTTPClient(httpClient), deepl.Middlewares(block))
+
+	_, err := client.Usage(context.Background())
+
+	assert.True(t, errors.Is(err, errBlocked))
+}
+
+func TestMiddlewares_retry(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"translations": [{"text": "Hallo"}]}`))
+	}))
+	defer server.Close()
+
+	var calls []string
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}),
+		deepl.Middlewares(recordingMiddleware("a", &calls)),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []string{
+		"a request", "a response",
+		"a request", "a response",
+		"a request", "a response",
+	}, calls, "middlewares should be called for every attempt")
+}
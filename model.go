@@ -0,0 +1,27 @@
+package deepl
+
+const (
+	// LatencyOptimized uses the lower latency "classic" translation models.
+	LatencyOptimized Model = "latency_optimized"
+	// QualityOptimized uses the higher quality, higher latency "next-gen"
+	// translation models. Requests fail if the language pair is not supported
+	// by these models.
+	QualityOptimized Model = "quality_optimized"
+	// PreferQualityOptimized uses the quality optimized models if they
+	// support the language pair and falls back to the latency optimized
+	// models otherwise.
+	PreferQualityOptimized Model = "prefer_quality_optimized"
+)
+
+// Model is a `model_type` option.
+type Model string
+
+// Value returns the request value for m.
+func (m Model) Value() string {
+	return string(m)
+}
+
+// String returns the model type as a [string].
+func (m Model) String() string {
+	return m.Value()
+}
@@ -0,0 +1,21 @@
+package deepl_test
+
+import (
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModel_Value_String(t *testing.T) {
+	tests := map[deepl.Model]string{
+		deepl.LatencyOptimized:       "latency_optimized",
+		deepl.QualityOptimized:       "quality_optimized",
+		deepl.PreferQualityOptimized: "prefer_quality_optimized",
+	}
+
+	for m, v := range tests {
+		assert.Equal(t, m.Value(), v)
+		assert.Equal(t, m.String(), v)
+	}
+}
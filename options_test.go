@@ -1,7 +1,6 @@
 package deepl_test
 
 import (
-	"net/url"
 	"strings"
 	"testing"
 
@@ -10,21 +9,22 @@ import (
 )
 
 func TestSourceLang(t *testing.T) {
-	vals := make(url.Values)
-	assert.Equal(t, "", vals.Get("source_lang"))
-	deepl.SourceLang(deepl.German)(vals)
-	assert.Equal(t, string(deepl.German), vals.Get("source_lang"))
+	var opts deepl.TranslateOptions
+	assert.Equal(t, "", opts.Values().Get("source_lang"))
+	deepl.SourceLang(deepl.German)(&opts)
+	assert.Equal(t, deepl.German, opts.SourceLang)
+	assert.Equal(t, string(deepl.German), opts.Values().Get("source_lang"))
 }
 
 func TestShowBilledChars(t *testing.T) {
-	vals := make(url.Values)
-	assert.Equal(t, "", vals.Get("show_billed_characters"))
-	deepl.ShowBilledChars(true)(vals)
-	assert.Equal(t, "1", vals.Get("show_billed_characters"))
-	deepl.ShowBilledChars(false)(vals)
-	assert.Equal(t, "0", vals.Get("show_billed_characters"))
-	deepl.ShowBilledChars(true)(vals)
-	assert.Equal(t, "1", vals.Get("show_billed_characters"))
+	var opts deepl.TranslateOptions
+	assert.Equal(t, "", opts.Values().Get("show_billed_characters"))
+	deepl.ShowBilledChars(true)(&opts)
+	assert.Equal(t, "1", opts.Values().Get("show_billed_characters"))
+	deepl.ShowBilledChars(false)(&opts)
+	assert.Equal(t, "0", opts.Values().Get("show_billed_characters"))
+	deepl.ShowBilledChars(true)(&opts)
+	assert.Equal(t, "1", opts.Values().Get("show_billed_characters"))
 }
 
 func TestSplitSentences(t *testing.T) {
@@ -36,22 +36,21 @@ func TestSplitSentences(t *testing.T) {
 
 	for _, split := range splits {
 		t.Run(split.String(), func(t *testing.T) {
-			vals := make(url.Values)
-			deepl.SplitSentences(split)(vals)
-			assert.Equal(t, vals.Get("split_sentences"), split.Value())
+			opts := deepl.NewTranslateOptions(deepl.SplitSentences(split))
+			assert.Equal(t, opts.Values().Get("split_sentences"), split.Value())
 		})
 	}
 }
 
 func TestPreserveFormatting(t *testing.T) {
-	vals := make(url.Values)
-	assert.Equal(t, "", vals.Get("preserve_formatting"))
-	deepl.PreserveFormatting(true)(vals)
-	assert.Equal(t, "1", vals.Get("preserve_formatting"))
-	deepl.PreserveFormatting(false)(vals)
-	assert.Equal(t, "0", vals.Get("preserve_formatting"))
-	deepl.PreserveFormatting(true)(vals)
-	assert.Equal(t, "1", vals.Get("preserve_formatting"))
+	var opts deepl.TranslateOptions
+	assert.Equal(t, "", opts.Values().Get("preserve_formatting"))
+	deepl.PreserveFormatting(true)(&opts)
+	assert.Equal(t, "1", opts.Values().Get("preserve_formatting"))
+	deepl.PreserveFormatting(false)(&opts)
+	assert.Equal(t, "0", opts.Values().Get("preserve_formatting"))
+	deepl.PreserveFormatting(true)(&opts)
+	assert.Equal(t, "1", opts.Values().Get("preserve_formatting"))
 }
 
 func TestFormality(t *testing.T) {
@@ -63,9 +62,8 @@ func TestFormality(t *testing.T) {
 
 	for _, f := range formalities {
 		t.Run(f.String(), func(t *testing.T) {
-			vals := make(url.Values)
-			deepl.Formality(f)(vals)
-			assert.Equal(t, f.Value(), vals.Get("formality"))
+			opts := deepl.NewTranslateOptions(deepl.Formality(f))
+			assert.Equal(t, f.Value(), opts.Values().Get("formality"))
 		})
 	}
 }
@@ -78,9 +76,8 @@ func TestTagHandling(t *testing.T) {
 
 	for _, s := range strategies {
 		t.Run(s.String(), func(t *testing.T) {
-			vals := make(url.Values)
-			deepl.TagHandling(s)(vals)
-			assert.Equal(t, s.Value(), vals.Get("tag_handling"))
+			opts := deepl.NewTranslateOptions(deepl.TagHandling(s))
+			assert.Equal(t, s.Value(), opts.Values().Get("tag_handling"))
 		})
 	}
 }
@@ -88,8 +85,60 @@ func TestTagHandling(t *testing.T) {
 func TestIgnoreTags(t *testing.T) {
 	tags := []string{"foo", "bar", "baz"}
 
-	vals := make(url.Values)
-	deepl.IgnoreTags(tags...)(vals)
+	opts := deepl.NewTranslateOptions(deepl.IgnoreTags(tags...))
 
-	assert.Equal(t, strings.Join(tags, ","), vals.Get("ignore_tags"))
+	assert.Equal(t, strings.Join(tags, ","), opts.Values().Get("ignore_tags"))
+}
+
+func TestGlossaryID(t *testing.T) {
+	opts := deepl.NewTranslateOptions(deepl.GlossaryID("glossary-id"))
+
+	assert.Equal(t, "glossary-id", opts.GlossaryID)
+	assert.Equal(t, "glossary-id", opts.Values().Get("glossary_id"))
+}
+
+func TestContext(t *testing.T) {
+	opts := deepl.NewTranslateOptions(deepl.Context("A greeting."))
+
+	assert.Equal(t, "A greeting.", opts.Values().Get("context"))
+}
+
+func TestTranslateOptions_Values_zero(t *testing.T) {
+	var opts deepl.TranslateOptions
+	assert.Empty(t, opts.Values())
+}
+
+func TestNewTranslateRequest(t *testing.T) {
+	req := deepl.NewTranslateRequest(
+		[]string{"Hello", "World"},
+		deepl.German,
+		deepl.SourceLang(deepl.English),
+		deepl.Formality(deepl.MoreFormal),
+	)
+
+	assert.Equal(t, deepl.TranslateRequest{
+		Texts:      []string{"Hello", "World"},
+		TargetLang: deepl.German,
+		TranslateOptions: deepl.TranslateOptions{
+			SourceLang: deepl.English,
+			Formality:  deepl.MoreFormal,
+		},
+	}, req)
+	assert.Equal(t, 10, req.Characters())
+	assert.Equal(t, "formality=more&source_lang=EN&target_lang=DE&text=Hello&text=World", req.Values().Encode())
+}
+
+func TestModelType(t *testing.T) {
+	models := []deepl.Model{
+		deepl.LatencyOptimized,
+		deepl.QualityOptimized,
+		deepl.PreferQualityOptimized,
+	}
+
+	for _, m := range models {
+		t.Run(m.String(), func(t *testing.T) {
+			opts := deepl.NewTranslateOptions(deepl.ModelType(m))
+			assert.Equal(t, m.Value(), opts.Values().Get("model_type"))
+		})
+	}
 }
@@ -0,0 +1,183 @@
+package deepl
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	httpi "github.com/bounoable/deepl/http"
+)
+
+// RateLimits configures the client-side rate limiting of a Client. Zero values
+// disable the respective limit.
+type RateLimits struct {
+	// RequestsPerSecond is the maximum sustained number of requests per second.
+	RequestsPerSecond float64
+
+	// Burst is the maximum number of requests that can be made at once. It
+	// defaults to RequestsPerSecond (but at least 1).
+	Burst int
+
+	// CharactersPerMinute is the maximum number of characters that are sent
+	// for translation per minute. A single request that contains more
+	// characters than the limit waits until the full budget is available.
+	CharactersPerMinute int
+
+	// MaxInFlight is the maximum number of concurrent requests. A request is
+	// in flight until its response body is closed.
+	MaxInFlight int
+}
+
+// RateLimit returns a ClientOption that limits the rate of requests that the
+// Client sends to DeepL. The limits apply to all endpoints and are shared by
+// all goroutines that use the Client. Requests wait for the limiter until
+// their context is canceled.
+func RateLimit(limits RateLimits) ClientOption {
+	l := &limiter{}
+	if limits.RequestsPerSecond > 0 {
+		burst := float64(limits.Burst)
+		if burst <= 0 {
+			burst = math.Max(1, math.Ceil(limits.RequestsPerSecond))
+		}
+		l.requests = newTokenBucket(limits.RequestsPerSecond, burst)
+	}
+	if limits.CharactersPerMinute > 0 {
+		chars := float64(limits.CharactersPerMinute)
+		l.characters = newTokenBucket(chars/60, chars)
+	}
+	if limits.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limits.MaxInFlight)
+	}
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+type limiter struct {
+	requests   *tokenBucket
+	characters *tokenBucket
+	inFlight   chan struct{}
+}
+
+// wait blocks until a request with the given number of characters may be
+// sent. The returned function must be called when the request is done.
+func (l *limiter) wait(ctx context.Context, chars int) (func(), error) {
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.inFlight != nil {
+			<-l.inFlight
+		}
+	}
+
+	if l.requests != nil {
+		if err := l.requests.wait(ctx, 1); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	if l.characters != nil && chars > 0 {
+		if err := l.characters.wait(ctx, float64(chars)); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	return release, nil
+}
+
+type limitedClient struct {
+	next    httpi.Client
+	limiter *limiter
+}
+
+func (lc *limitedClient) Do(req *http.Request) (*http.Response, error) {
+	var chars int
+	if treq, ok := TranslateRequestFromContext(req.Context()); ok {
+		chars = treq.Characters()
+	}
+	release, err := lc.limiter.wait(req.Context(), chars)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := lc.next.Do(req)
+	if err != nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+type releaseBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
+
+type tokenBucket struct {
+	rate  float64 // tokens per second
+	burst float64
+
+	mux    sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   time.Now(),
+	}
+}
+
+// wait takes n tokens from the bucket and blocks until they are available.
+// If n exceeds the burst size, wait blocks until the bucket is full.
+func (b *tokenBucket) wait(ctx context.Context, n float64) error {
+	if n > b.burst {
+		n = b.burst
+	}
+
+	b.mux.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens -= n
+	if b.tokens >= 0 {
+		b.mux.Unlock()
+		return nil
+	}
+	wait := time.Duration(-b.tokens / b.rate * float64(time.Second))
+	b.mux.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		b.mux.Lock()
+		b.tokens += n
+		b.mux.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+package deepl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTranslateServer(handle func()) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handle != nil {
+			handle()
+		}
+		w.Write([]byte(`{"translations": [{"detected_source_language": "EN", "text": "Hallo"}]}`))
+	}))
+}
+
+func TestRateLimit_requestsPerSecond(t *testing.T) {
+	server := newTranslateServer(nil)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.RateLimit(deepl.RateLimits{RequestsPerSecond: 20, Burst: 1}),
+	)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+		require.NoError(t, err)
+	}
+
+	assert.True(t, time.Since(start) >= 190*time.Millisecond)
+}
+
+func TestRateLimit_charactersPerMinute(t *testing.T) {
+	server := newTranslateServer(nil)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.RateLimit(deepl.RateLimits{CharactersPerMinute: 600}),
+	)
+
+	start := time.Now()
+	_, err := client.TranslateMany(context.Background(), []string{strings.Repeat("a", 600)}, deepl.German)
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) < 100*time.Millisecond)
+
+	_, _, err = client.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+	assert.True(t, time.Since(start) >= 450*time.Millisecond)
+}
+
+func TestRateLimit_maxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := newTranslateServer(func() {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	})
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.RateLimit(deepl.RateLimits{MaxInFlight: 2}),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+}
+
+func TestRateLimit_contextCanceled(t *testing.T) {
+	server := newTranslateServer(nil)
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.RateLimit(deepl.RateLimits{RequestsPerSecond: 0.1}),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, _, err = client.Translate(ctx, "Hello", deepl.German)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
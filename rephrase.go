@@ -0,0 +1,171 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultWritingStyle is the default writing style.
+	DefaultWritingStyle WritingStyle = "default"
+	// SimpleWritingStyle uses plain and easy to understand language.
+	SimpleWritingStyle WritingStyle = "simple"
+	// BusinessWritingStyle uses language that is suitable for business
+	// communication.
+	BusinessWritingStyle WritingStyle = "business"
+	// AcademicWritingStyle uses language that is suitable for academic texts.
+	AcademicWritingStyle WritingStyle = "academic"
+	// CasualWritingStyle uses informal language.
+	CasualWritingStyle WritingStyle = "casual"
+)
+
+const (
+	// DefaultTone is the default tone.
+	DefaultTone Tone = "default"
+	// EnthusiasticTone makes the text sound enthusiastic.
+	EnthusiasticTone Tone = "enthusiastic"
+	// FriendlyTone makes the text sound friendly.
+	FriendlyTone Tone = "friendly"
+	// ConfidentTone makes the text sound confident.
+	ConfidentTone Tone = "confident"
+	// DiplomaticTone makes the text sound diplomatic.
+	DiplomaticTone Tone = "diplomatic"
+)
+
+// WritingStyle is a `writing_style` option.
+type WritingStyle string
+
+// Tone is a `tone` option.
+type Tone string
+
+// A RephraseOption configures a text improvement request.
+type RephraseOption func(*RephraseOptions)
+
+// RephraseOptions are the parameters of a text improvement request besides
+// the texts and the target language. Zero values are not sent to DeepL.
+type RephraseOptions struct {
+	WritingStyle WritingStyle
+	Tone         Tone
+}
+
+// Value returns the request value for s.
+func (s WritingStyle) Value() string {
+	return string(s)
+}
+
+// String returns the writing style as a [string].
+func (s WritingStyle) String() string {
+	return s.Value()
+}
+
+// Prefer returns the "prefer_" variant of s, which falls back to the default
+// writing style if the target language does not support s.
+func (s WritingStyle) Prefer() WritingStyle {
+	if s == DefaultWritingStyle || strings.HasPrefix(string(s), "prefer_") {
+		return s
+	}
+	return "prefer_" + s
+}
+
+// Value returns the request value for t.
+func (t Tone) Value() string {
+	return string(t)
+}
+
+// String returns the tone as a [string].
+func (t Tone) String() string {
+	return t.Value()
+}
+
+// Prefer returns the "prefer_" variant of t, which falls back to the default
+// tone if the target language does not support t.
+func (t Tone) Prefer() Tone {
+	if t == DefaultTone || strings.HasPrefix(string(t), "prefer_") {
+		return t
+	}
+	return "prefer_" + t
+}
+
+// RephraseStyle returns a RephraseOption that sets the `writing_style` DeepL
+// option. A writing style cannot be combined with a tone.
+func RephraseStyle(style WritingStyle) RephraseOption {
+	return func(o *RephraseOptions) {
+		o.WritingStyle = style
+	}
+}
+
+// RephraseTone returns a RephraseOption that sets the `tone` DeepL option. A
+// tone cannot be combined with a writing style.
+func RephraseTone(tone Tone) RephraseOption {
+	return func(o *RephraseOptions) {
+		o.Tone = tone
+	}
+}
+
+// Values returns the form values of the options.
+func (o RephraseOptions) Values() url.Values {
+	vals := make(url.Values)
+	if o.WritingStyle != "" {
+		vals.Set("writing_style", o.WritingStyle.Value())
+	}
+	if o.Tone != "" {
+		vals.Set("tone", o.Tone.Value())
+	}
+	return vals
+}
+
+// Rephrase as per
+// https://developers.deepl.com/docs/api-reference/improve-text
+//
+// Rephrase improves the provided texts using DeepL Write and returns an
+// Improvement for every input text. The order of the improved texts is
+// guaranteed to be the same as the order of the input texts. If targetLang is
+// empty, the texts are improved in their detected source language.
+func (c *Client) Rephrase(ctx context.Context, texts []string, targetLang Language, opts ...RephraseOption) ([]Improvement, error) {
+	var options RephraseOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	vals := options.Values()
+	if targetLang != "" {
+		vals.Set("target_lang", string(targetLang))
+	}
+	if len(texts) > 0 {
+		vals["text"] = texts
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.rephraseURL, strings.NewReader(vals.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResp(resp)
+	}
+
+	var response struct {
+		Improvements []Improvement `json:"improvements"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	if len(response.Improvements) != len(texts) {
+		return nil, fmt.Errorf("deepl responded with %d improvements for %d texts", len(response.Improvements), len(texts))
+	}
+
+	return response.Improvements, nil
+}
@@ -0,0 +1,121 @@
+package deepl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Rephrase(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/write/rephrase", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		w.Write([]byte(`{"improvements": [
+			{"text": "This is a sample sentence.", "target_language": "en-US", "detected_source_language": "en"},
+			{"text": "Another sentence.", "target_language": "en-US", "detected_source_language": "en"}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	improvements, err := client.Rephrase(
+		context.Background(),
+		[]string{"this is a sample sentence", "another sentense"},
+		deepl.EnglishAmerican,
+		deepl.RephraseStyle(deepl.BusinessWritingStyle),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{
+		"text":          {"this is a sample sentence", "another sentense"},
+		"target_lang":   {"EN-US"},
+		"writing_style": {"business"},
+	}, form)
+	assert.Equal(t, []deepl.Improvement{
+		{Text: "This is a sample sentence.", TargetLanguage: "en-US", DetectedSourceLanguage: "en"},
+		{Text: "Another sentence.", TargetLanguage: "en-US", DetectedSourceLanguage: "en"},
+	}, improvements)
+}
+
+func TestClient_Rephrase_tone(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		w.Write([]byte(`{"improvements": [{"text": "Hallo!", "target_language": "de", "detected_source_language": "de"}]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.Rephrase(context.Background(), []string{"hallo"}, "", deepl.RephraseTone(deepl.FriendlyTone.Prefer()))
+
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"text": {"hallo"}, "tone": {"prefer_friendly"}}, form)
+}
+
+func TestClient_Rephrase_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"message": "Bad request. Reason: Setting both writing_style and tone is not supported"}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.Rephrase(
+		context.Background(),
+		[]string{"hello"},
+		deepl.EnglishAmerican,
+		deepl.RephraseStyle(deepl.CasualWritingStyle),
+		deepl.RephraseTone(deepl.FriendlyTone),
+	)
+
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+}
+
+func TestWritingStyle_Value_String(t *testing.T) {
+	tests := map[deepl.WritingStyle]string{
+		deepl.DefaultWritingStyle:           "default",
+		deepl.SimpleWritingStyle:            "simple",
+		deepl.BusinessWritingStyle:          "business",
+		deepl.AcademicWritingStyle:          "academic",
+		deepl.CasualWritingStyle:            "casual",
+		deepl.AcademicWritingStyle.Prefer(): "prefer_academic",
+		deepl.DefaultWritingStyle.Prefer():  "default",
+	}
+
+	for s, v := range tests {
+		assert.Equal(t, s.Value(), v)
+		assert.Equal(t, s.String(), v)
+	}
+}
+
+func TestTone_Value_String(t *testing.T) {
+	tests := map[deepl.Tone]string{
+		deepl.DefaultTone:                      "default",
+		deepl.EnthusiasticTone:                 "enthusiastic",
+		deepl.FriendlyTone:                     "friendly",
+		deepl.ConfidentTone:                    "confident",
+		deepl.DiplomaticTone:                   "diplomatic",
+		deepl.DiplomaticTone.Prefer():          "prefer_diplomatic",
+		deepl.DiplomaticTone.Prefer().Prefer(): "prefer_diplomatic",
+	}
+
+	for tone, v := range tests {
+		assert.Equal(t, tone.Value(), v)
+		assert.Equal(t, tone.String(), v)
+	}
+}
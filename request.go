@@ -0,0 +1,222 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const (
+	// FormEncoding sends translation requests as
+	// application/x-www-form-urlencoded (default).
+	FormEncoding Encoding = "form"
+	// JSONEncoding sends translation requests as application/json.
+	JSONEncoding Encoding = "json"
+)
+
+// Encoding is the encoding of translation request bodies.
+type Encoding string
+
+// RequestEncoding returns a ClientOption that sets the encoding of
+// translation request bodies. Both encodings send the same TranslateRequest.
+func RequestEncoding(enc Encoding) ClientOption {
+	return func(c *Client) {
+		c.encoding = enc
+	}
+}
+
+// TranslateOptions are the parameters of a translation request besides the
+// texts and the target language. TranslateOptions are populated by
+// TranslateOption functions. Zero values are not sent to DeepL.
+type TranslateOptions struct {
+	SourceLang           Language
+	ShowBilledCharacters *bool
+	SplitSentences       SplitSentence
+	PreserveFormatting   *bool
+	Formality            Formal
+	ModelType            Model
+	TagHandling          TagHandlingStrategy
+	IgnoreTags           []string
+	NonSplittingTags     []string
+	SplittingTags        []string
+	OutlineDetection     *bool
+	GlossaryID           string
+	Context              string
+}
+
+// NewTranslateOptions returns the TranslateOptions that are configured by
+// opts.
+func NewTranslateOptions(opts ...TranslateOption) TranslateOptions {
+	var options TranslateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// Values returns the form values of the options.
+func (o TranslateOptions) Values() url.Values {
+	vals := make(url.Values)
+	if o.SourceLang != "" {
+		vals.Set("source_lang", string(o.SourceLang))
+	}
+	if o.ShowBilledCharacters != nil {
+		vals.Set("show_billed_characters", boolString(*o.ShowBilledCharacters))
+	}
+	if o.SplitSentences != "" {
+		vals.Set("split_sentences", o.SplitSentences.Value())
+	}
+	if o.PreserveFormatting != nil {
+		vals.Set("preserve_formatting", boolString(*o.PreserveFormatting))
+	}
+	if o.Formality != "" {
+		vals.Set("formality", o.Formality.Value())
+	}
+	if o.ModelType != "" {
+		vals.Set("model_type", o.ModelType.Value())
+	}
+	if v := o.TagHandling.Value(); v != "" {
+		vals.Set("tag_handling", v)
+	}
+	if len(o.IgnoreTags) > 0 {
+		vals.Set("ignore_tags", strings.Join(o.IgnoreTags, ","))
+	}
+	if len(o.NonSplittingTags) > 0 {
+		vals.Set("non_splitting_tags", strings.Join(o.NonSplittingTags, ","))
+	}
+	if len(o.SplittingTags) > 0 {
+		vals.Set("splitting_tags", strings.Join(o.SplittingTags, ","))
+	}
+	if o.OutlineDetection != nil {
+		vals.Set("outline_detection", boolString(*o.OutlineDetection))
+	}
+	if o.GlossaryID != "" {
+		vals.Set("glossary_id", o.GlossaryID)
+	}
+	if o.Context != "" {
+		vals.Set("context", o.Context)
+	}
+	return vals
+}
+
+// A TranslateRequest is a request to the translation endpoint.
+type TranslateRequest struct {
+	Texts      []string
+	TargetLang Language
+	TranslateOptions
+}
+
+// NewTranslateRequest returns the TranslateRequest that TranslateMany sends
+// for the given texts, target language and options.
+func NewTranslateRequest(texts []string, targetLang Language, opts ...TranslateOption) TranslateRequest {
+	return TranslateRequest{
+		Texts:            texts,
+		TargetLang:       targetLang,
+		TranslateOptions: NewTranslateOptions(opts...),
+	}
+}
+
+// Values returns the form values of the request. Use MarshalJSON for the JSON
+// encoding of the request.
+func (r TranslateRequest) Values() url.Values {
+	vals := r.TranslateOptions.Values()
+	vals.Set("target_lang", string(r.TargetLang))
+	if len(r.Texts) > 0 {
+		vals["text"] = r.Texts
+	}
+	return vals
+}
+
+// MarshalJSON returns the JSON body of the request.
+func (r TranslateRequest) MarshalJSON() ([]byte, error) {
+	body := translateRequestJSON{
+		Text:             r.Texts,
+		TargetLang:       r.TargetLang,
+		SourceLang:       r.SourceLang,
+		ShowBilled:       r.ShowBilledCharacters,
+		PreserveFormat:   r.PreserveFormatting,
+		Formality:        r.Formality.Value(),
+		ModelType:        r.ModelType.Value(),
+		TagHandling:      r.TagHandling.Value(),
+		IgnoreTags:       r.IgnoreTags,
+		NonSplittingTags: r.NonSplittingTags,
+		SplittingTags:    r.SplittingTags,
+		OutlineDetection: r.OutlineDetection,
+		GlossaryID:       r.GlossaryID,
+		Context:          r.Context,
+	}
+	if body.Text == nil {
+		body.Text = []string{}
+	}
+	if r.SplitSentences != "" {
+		body.SplitSentences = r.SplitSentences.Value()
+	}
+	return marshalJSON(body)
+}
+
+// marshalJSON is json.Marshal without HTML escaping. HTML escaping would
+// encode '<', '>' and '&' as 6 bytes each, which inflates requests with
+// TagHandling content beyond the size of the equivalent form body.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+type translateRequestJSON struct {
+	Text             []string `json:"text"`
+	TargetLang       Language `json:"target_lang"`
+	SourceLang       Language `json:"source_lang,omitempty"`
+	ShowBilled       *bool    `json:"show_billed_characters,omitempty"`
+	SplitSentences   string   `json:"split_sentences,omitempty"`
+	PreserveFormat   *bool    `json:"preserve_formatting,omitempty"`
+	Formality        string   `json:"formality,omitempty"`
+	ModelType        string   `json:"model_type,omitempty"`
+	TagHandling      string   `json:"tag_handling,omitempty"`
+	IgnoreTags       []string `json:"ignore_tags,omitempty"`
+	NonSplittingTags []string `json:"non_splitting_tags,omitempty"`
+	SplittingTags    []string `json:"splitting_tags,omitempty"`
+	OutlineDetection *bool    `json:"outline_detection,omitempty"`
+	GlossaryID       string   `json:"glossary_id,omitempty"`
+	Context          string   `json:"context,omitempty"`
+}
+
+// Characters returns the number of characters of the texts in the request.
+func (r TranslateRequest) Characters() int {
+	return countCharacters(r.Texts)
+}
+
+type translateRequestKey struct{}
+
+// TranslateRequestFromContext returns the TranslateRequest of the translation
+// request that ctx belongs to. Use TranslateRequestFromContext to inspect the
+// parameters of a translation request in a custom http client:
+//
+//	func (c *loggingClient) Do(req *http.Request) (*http.Response, error) {
+//		if treq, ok := deepl.TranslateRequestFromContext(req.Context()); ok {
+//			log.Printf("translating %d texts into %s", len(treq.Texts), treq.TargetLang)
+//		}
+//		return c.next.Do(req)
+//	}
+func TranslateRequestFromContext(ctx context.Context) (TranslateRequest, bool) {
+	req, ok := ctx.Value(translateRequestKey{}).(TranslateRequest)
+	return req, ok
+}
+
+func withTranslateRequest(ctx context.Context, req TranslateRequest) context.Context {
+	return context.WithValue(ctx, translateRequestKey{}, req)
+}
+
+func countCharacters(texts []string) int {
+	var n int
+	for _, text := range texts {
+		n += len([]rune(text))
+	}
+	return n
+}
@@ -0,0 +1,125 @@
+package deepl_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var requestEncodingTests = map[string]deepl.TranslateRequest{
+	"no options": deepl.NewTranslateRequest([]string{"Hello"}, deepl.German),
+	"no texts":   deepl.NewTranslateRequest(nil, deepl.German),
+	"all options": deepl.NewTranslateRequest(
+		[]string{"Hello", "<p>World</p>"},
+		deepl.German,
+		deepl.SourceLang(deepl.English),
+		deepl.ShowBilledChars(true),
+		deepl.SplitSentences(deepl.SplitNoNewlines),
+		deepl.PreserveFormatting(false),
+		deepl.Formality(deepl.MoreFormal),
+		deepl.ModelType(deepl.QualityOptimized),
+		deepl.TagHandling(deepl.XMLTagHandling),
+		deepl.IgnoreTags("x", "y"),
+		deepl.NonSplittingTags("b"),
+		deepl.SplittingTags("p", "div"),
+		deepl.OutlineDetection(false),
+		deepl.GlossaryID("glossary-id"),
+		deepl.Context("A greeting."),
+	),
+}
+
+func TestTranslateRequest_encodingsAreEquivalent(t *testing.T) {
+	for name, req := range requestEncodingTests {
+		t.Run(name, func(t *testing.T) {
+			b, err := json.Marshal(req)
+			require.NoError(t, err)
+
+			var fromJSON map[string]interface{}
+			require.NoError(t, json.Unmarshal(b, &fromJSON))
+
+			assert.Equal(t, normalizeForm(req.Values()), fromJSON)
+		})
+	}
+}
+
+// normalizeForm converts form values into the structure of the equivalent
+// JSON request body.
+func normalizeForm(vals url.Values) map[string]interface{} {
+	out := make(map[string]interface{})
+	if _, ok := vals["text"]; !ok {
+		out["text"] = []interface{}{}
+	}
+	for key, values := range vals {
+		switch key {
+		case "text":
+			out[key] = stringsToInterfaces(values)
+		case "ignore_tags", "non_splitting_tags", "splitting_tags":
+			out[key] = stringsToInterfaces(strings.Split(values[0], ","))
+		case "show_billed_characters", "preserve_formatting", "outline_detection":
+			out[key] = values[0] == "1"
+		default:
+			out[key] = values[0]
+		}
+	}
+	return out
+}
+
+func stringsToInterfaces(values []string) []interface{} {
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
+
+func TestRequestEncoding_json(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Write([]byte(`{"translations": [{"detected_source_language": "EN", "text": "Hallo"}, {"detected_source_language": "EN", "text": "Welt"}]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.RequestEncoding(deepl.JSONEncoding))
+
+	translations, err := client.TranslateMany(
+		context.Background(),
+		[]string{"Hello", "World"},
+		deepl.German,
+		deepl.Formality(deepl.LessFormal),
+		deepl.IgnoreTags("x"),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"text":        []interface{}{"Hello", "World"},
+		"target_lang": "DE",
+		"formality":   "less",
+		"ignore_tags": []interface{}{"x"},
+	}, body)
+	assert.Equal(t, "Hallo", translations[0].Text)
+	assert.Equal(t, "Welt", translations[1].Text)
+}
+
+func TestRequestEncoding_form(t *testing.T) {
+	var forms []url.Values
+	server := newFormRecorder(t, &forms)
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL), deepl.RequestEncoding(deepl.FormEncoding))
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, url.Values{"text": {"Hello"}, "target_lang": {"DE"}}, forms[0])
+}
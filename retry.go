@@ -0,0 +1,190 @@
+package deepl
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	httpi "github.com/bounoable/deepl/http"
+)
+
+// A RetryPolicy configures how failed requests are retried. Zero values are
+// replaced by the defaults of DefaultRetryPolicy.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	MaxAttempts int
+
+	// BaseDelay is the delay before the first retry. The delay doubles with
+	// every retry.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the delay between two attempts, including delays that
+	// DeepL requests with a Retry-After header.
+	MaxDelay time.Duration
+
+	// Jitter randomizes the delay between attempts. A Jitter of 0.2 reduces
+	// each delay by up to 20%. It must be between 0 and 1.
+	Jitter float64
+
+	// RetryableStatus are the HTTP status codes that are retried. Requests
+	// that fail without a response (e.g. because of a network error) are
+	// always retried.
+	RetryableStatus []int
+
+	// NonIdempotent enables retries of requests that create resources on the
+	// DeepL side (glossary creation and document uploads). A retry of such a
+	// request may create the resource twice.
+	NonIdempotent bool
+}
+
+// DefaultRetryPolicy is the RetryPolicy that is used for zero values of a
+// RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Jitter:      0.2,
+	RetryableStatus: []int{
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
+	},
+}
+
+// Retry returns a ClientOption that retries failed requests according to the
+// given RetryPolicy. If DeepL responds with a Retry-After header, the delay
+// from the header (capped at MaxDelay) is used instead of the exponential
+// backoff. A retry is never started if it would exceed the deadline of the
+// request context; the last response is returned instead.
+func Retry(policy RetryPolicy) ClientOption {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if policy.MaxDelay <= 0 {
+		policy.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		policy.Jitter = DefaultRetryPolicy.Jitter
+	}
+	if policy.RetryableStatus == nil {
+		policy.RetryableStatus = DefaultRetryPolicy.RetryableStatus
+	}
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+type nonIdempotentKey struct{}
+
+// nonIdempotent marks requests that are made with the returned context as
+// requests that must not be retried unless RetryPolicy.NonIdempotent is set.
+func nonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentKey{}, true)
+}
+
+type retryClient struct {
+	next   httpi.Client
+	policy RetryPolicy
+}
+
+func (rc *retryClient) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	retryable := rc.policy.NonIdempotent || ctx.Value(nonIdempotentKey{}) == nil
+	if req.Body != nil && req.GetBody == nil {
+		retryable = false
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := rc.next.Do(req)
+		if !retryable || attempt >= rc.policy.MaxAttempts || ctx.Err() != nil || !rc.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := rc.delay(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+	}
+}
+
+func (rc *retryClient) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return resp == nil
+	}
+	for _, code := range rc.policy.RetryableStatus {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *retryClient) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > rc.policy.MaxDelay {
+				wait = rc.policy.MaxDelay
+			}
+			return wait
+		}
+	}
+
+	wait := rc.policy.BaseDelay << uint(attempt-1)
+	if wait <= 0 || wait > rc.policy.MaxDelay {
+		wait = rc.policy.MaxDelay
+	}
+	if rc.policy.Jitter > 0 {
+		wait -= time.Duration(rand.Float64() * rc.policy.Jitter * float64(wait))
+	}
+	return wait
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func retryAfter(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
@@ -0,0 +1,198 @@
+package deepl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetry(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, []string{"Hello"}, r.Form["text"], "form body must be rewound between attempts")
+
+		if requests++; requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"translations": [{"detected_source_language": "EN", "text": "Hallo"}]}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}),
+	)
+
+	text, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Hallo", text)
+	assert.Equal(t, 3, requests)
+}
+
+func TestRetry_maxAttempts(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond}),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	assert.True(t, errors.Is(err, deepl.ErrTooManyRequests))
+	assert.Equal(t, 4, requests)
+}
+
+func TestRetry_nonRetryableStatus(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}),
+	)
+
+	_, _, err := client.Translate(context.Background(), "Hello", deepl.German)
+
+	assert.True(t, errors.Is(err, deepl.ErrBadRequest))
+	assert.Equal(t, 1, requests)
+}
+
+func TestRetry_retryAfter(t *testing.T) {
+	var requests []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, time.Now())
+		if len(requests) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"character_count": 1}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}),
+	)
+
+	_, err := client.Usage(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, requests, 2)
+	assert.True(t, requests[1].Sub(requests[0]) >= time.Second)
+}
+
+func TestRetry_retryAfterMaxDelay(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests++; requests == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"character_count": 1}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{MaxDelay: 10 * time.Millisecond}),
+	)
+
+	start := time.Now()
+	_, err := client.Usage(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.True(t, time.Since(start) < time.Second, "Retry-After should be capped at MaxDelay")
+}
+
+func TestRetry_contextDeadline(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Usage(ctx)
+
+	assert.True(t, errors.Is(err, deepl.ErrTooManyRequests))
+	assert.Equal(t, 1, requests)
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestRetry_glossaryCreation(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests++; requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"glossary_id": "glossary-id"}`))
+	}))
+	defer server.Close()
+
+	entries := []deepl.GlossaryEntry{{Source: "Hello", Target: "Hallo"}}
+
+	client := deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond}),
+	)
+
+	_, err := client.CreateGlossary(context.Background(), "example", deepl.English, deepl.German, entries)
+
+	assert.True(t, errors.Is(err, deepl.ErrServiceUnavailable))
+	assert.Equal(t, 1, requests)
+
+	requests = 0
+	client = deepl.New(
+		"an-auth-key",
+		deepl.BaseURL(server.URL),
+		deepl.Retry(deepl.RetryPolicy{BaseDelay: time.Millisecond, NonIdempotent: true}),
+	)
+
+	glossary, err := client.CreateGlossary(context.Background(), "example", deepl.English, deepl.German, entries)
+
+	require.NoError(t, err)
+	assert.Equal(t, "glossary-id", glossary.GlossaryID)
+	assert.Equal(t, 2, requests)
+}
@@ -24,3 +24,57 @@ func TestTagHandlingStrategy_Value_String(t *testing.T) {
 		}
 	}
 }
+
+func TestXMLTagOptions(t *testing.T) {
+	tests := map[string]struct {
+		opt   deepl.TranslateOption
+		key   string
+		value string
+	}{
+		"NonSplittingTags": {
+			opt:   deepl.NonSplittingTags("emphasis", "code"),
+			key:   "non_splitting_tags",
+			value: "emphasis,code",
+		},
+		"SplittingTags": {
+			opt:   deepl.SplittingTags("para", "title"),
+			key:   "splitting_tags",
+			value: "para,title",
+		},
+		"OutlineDetection(false)": {
+			opt:   deepl.OutlineDetection(false),
+			key:   "outline_detection",
+			value: "0",
+		},
+		"OutlineDetection(true)": {
+			opt:   deepl.OutlineDetection(true),
+			key:   "outline_detection",
+			value: "1",
+		},
+	}
+
+	for name, tt := range tests {
+		vals := deepl.NewTranslateOptions(
+			deepl.TagHandling(deepl.XMLTagHandling),
+			tt.opt,
+		).Values()
+
+		if got := vals.Get(tt.key); got != tt.value {
+			t.Errorf("%s: expected %s value %q; got %q", name, tt.key, tt.value, got)
+		}
+
+		if got := vals.Get("tag_handling"); got != "xml" {
+			t.Errorf("%s: expected tag_handling value %q; got %q", name, "xml", got)
+		}
+	}
+}
+
+func TestXMLTagOptions_unset(t *testing.T) {
+	vals := deepl.NewTranslateOptions(deepl.TagHandling(deepl.XMLTagHandling)).Values()
+
+	for _, key := range []string{"non_splitting_tags", "splitting_tags", "outline_detection"} {
+		if _, ok := vals[key]; ok {
+			t.Errorf("expected %s not to be set", key)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package deepl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Usage as per
+// https://www.deepl.com/docs-api/general/get-usage
+//
+// Use Usage to check the remaining quota before starting large translation
+// jobs:
+//
+//	usage, err := c.Usage(context.TODO())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	if usage.Remaining() >= 0 && usage.Remaining() < chars {
+//		log.Fatal("not enough characters left")
+//	}
+func (c *Client) Usage(ctx context.Context) (*Usage, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.usageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Add("Authorization", "DeepL-Auth-Key "+c.authKey)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errorFromResp(resp)
+	}
+
+	var response Usage
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("decode deepl response: %w", err)
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,88 @@
+package deepl_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bounoable/deepl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Usage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/usage", r.URL.Path)
+		assert.Equal(t, "DeepL-Auth-Key an-auth-key", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"character_count": 180118, "character_limit": 1250000}`))
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	usage, err := client.Usage(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, deepl.Usage{CharacterCount: 180118, CharacterLimit: 1250000}, *usage)
+}
+
+func TestClient_Usage_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := deepl.New("an-auth-key", deepl.BaseURL(server.URL))
+
+	_, err := client.Usage(context.Background())
+
+	var deeplError deepl.Error
+	require.True(t, errors.As(err, &deeplError))
+	assert.Equal(t, http.StatusForbidden, deeplError.Code)
+}
+
+func TestUsage_Remaining(t *testing.T) {
+	tests := map[string]struct {
+		usage     deepl.Usage
+		remaining int
+	}{
+		"no limit":      {usage: deepl.Usage{CharacterCount: 10}, remaining: -1},
+		"below limit":   {usage: deepl.Usage{CharacterCount: 10, CharacterLimit: 25}, remaining: 15},
+		"limit reached": {usage: deepl.Usage{CharacterCount: 25, CharacterLimit: 25}, remaining: 0},
+		"over limit":    {usage: deepl.Usage{CharacterCount: 30, CharacterLimit: 25}, remaining: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.remaining, tt.usage.Remaining())
+		})
+	}
+}
+
+func TestUsage_RemainingDocuments(t *testing.T) {
+	assert.Equal(t, -1, deepl.Usage{DocumentCount: 3}.RemainingDocuments())
+	assert.Equal(t, 7, deepl.Usage{DocumentCount: 3, DocumentLimit: 10}.RemainingDocuments())
+	assert.Equal(t, 0, deepl.Usage{DocumentCount: 12, DocumentLimit: 10}.RemainingDocuments())
+}
+
+func TestUsage_Exceeded(t *testing.T) {
+	tests := map[string]struct {
+		usage    deepl.Usage
+		exceeded bool
+	}{
+		"no limits":           {usage: deepl.Usage{CharacterCount: 10}, exceeded: false},
+		"below limits":        {usage: deepl.Usage{CharacterCount: 10, CharacterLimit: 20, DocumentCount: 1, DocumentLimit: 2}, exceeded: false},
+		"characters exceeded": {usage: deepl.Usage{CharacterCount: 20, CharacterLimit: 20}, exceeded: true},
+		"documents exceeded":  {usage: deepl.Usage{DocumentCount: 2, DocumentLimit: 2}, exceeded: true},
+		"team docs exceeded":  {usage: deepl.Usage{TeamDocumentCount: 5, TeamDocumentLimit: 5}, exceeded: true},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.exceeded, tt.usage.Exceeded())
+		})
+	}
+}